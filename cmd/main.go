@@ -19,20 +19,33 @@ import (
 
 func main() {
 	// Парсим флаги
-	mode := flag.String("mode", "", "Service mode: order-service, kitchen-worker, tracking-service, notification-subscriber")
+	mode := flag.String("mode", "", "Service mode: order-service, kitchen-worker, tracking-service, notification-subscriber, notification-preview")
 	port := flag.Int("port", 3000, "HTTP port for services that need it")
 	workerName := flag.String("worker-name", "", "Name for kitchen worker")
 	orderTypes := flag.String("order-types", "", "Comma-separated order types for kitchen worker")
 	prefetch := flag.Int("prefetch", 1, "Prefetch count for RabbitMQ")
+	schedule := flag.String("schedule", "fifo", "Order selection for kitchen worker with prefetch > 1: fifo, priority, shortest, edf")
+	maxCooking := flag.Int("max-cooking", 1, "Orders a kitchen worker cooks at once; keep it below --prefetch so --schedule has buffered orders to choose from")
 	heartbeatInterval := flag.Int("heartbeat-interval", 30, "Heartbeat interval in seconds")
 	maxConcurrent := flag.Int("max-concurrent", 50, "Max concurrent orders for order service")
+	timeScale := flag.Float64("time-scale", 1, "Simulation speed-up for cooking, heartbeats and liveness (60 = one minute per second)")
+	subscription := flag.String("subscription", "", "Durable subscription name for notification-subscriber (queue notifications.<name>); empty = temporary queue")
+	locale := flag.String("locale", "en", "Template locale for notification-preview: en, ru, kk")
+	channel := flag.String("channel", "sms", "Customer channel for notification-preview: email, sms")
+	status := flag.String("status", "ready", "Order status for notification-preview")
 
 	flag.Parse()
 
 	// Валидация обязательных флагов
 	if *mode == "" {
 		fmt.Println("Error: --mode flag is required")
-		fmt.Println("Available modes: order-service, kitchen-worker, tracking-service, notification-subscriber")
+		fmt.Println("Available modes: order-service, kitchen-worker, tracking-service, notification-subscriber, notification-preview")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *timeScale <= 0 {
+		fmt.Println("Error: --time-scale must be positive")
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -43,6 +56,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Предпросмотр шаблона уведомления — разовая команда, сервис не запускаем
+	if *mode == "notification-preview" {
+		preview := notificationcmd.PreviewConfig{
+			Locale:  *locale,
+			Channel: *channel,
+			Status:  *status,
+		}
+		if err := notificationcmd.Preview(os.Stdout, preview); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Контекст и cancel для управления жизненным циклом сервисов
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -57,6 +84,7 @@ func main() {
 		config := ordercmd.Config{
 			Port:          *port,
 			MaxConcurrent: *maxConcurrent,
+			TimeScale:     *timeScale,
 		}
 		wg.Add(1)
 		go func() {
@@ -70,6 +98,9 @@ func main() {
 			OrderType:         *orderTypes,
 			Prefetch:          *prefetch,
 			HeartbeatInterval: *heartbeatInterval,
+			Schedule:          *schedule,
+			MaxCooking:        *maxCooking,
+			TimeScale:         *timeScale,
 		}
 		wg.Add(1)
 		go func() {
@@ -79,7 +110,8 @@ func main() {
 
 	case "tracking-service":
 		config := trackingcmd.Config{
-			Port: *port,
+			Port:      *port,
+			TimeScale: *timeScale,
 		}
 		wg.Add(1)
 		go func() {
@@ -88,10 +120,14 @@ func main() {
 		}()
 
 	case "notification-subscriber":
+		config := notificationcmd.Config{
+			Subscription: *subscription,
+			TimeScale:    *timeScale,
+		}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			err = notificationcmd.Start(ctx)
+			err = notificationcmd.Start(ctx, config)
 		}()
 
 	default:
@@ -100,20 +136,32 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err != nil {
-		log.Fatalf("Service %s failed: %v", *mode, err)
-	}
+	// Сервис может завершиться сам (например, kitchen-worker после drain)
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
 
 	// Graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
-	<-sigCh
-	log.Println("Shutdown signal received, shutting down gracefully...")
-	cancel() // Прекращаем обработку запросов
+	select {
+	case <-sigCh:
+		log.Println("Shutdown signal received, shutting down gracefully...")
+		cancel() // Прекращаем обработку запросов
+
+		// Ждем завершения всех горутин
+		<-done
+	case <-done:
+		log.Println("Service stopped")
+	}
 
-	// Ждем завершения всех горутин
-	wg.Wait()
+	// Start возвращает ошибку вместо выхода из процесса — сообщаем о ней здесь
+	if err != nil {
+		log.Fatalf("Service %s failed: %v", *mode, err)
+	}
 
 	// Даем время для закрытия всех ресурсов (например, базы данных или RabbitMQ)
 	time.Sleep(2 * time.Second)
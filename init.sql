@@ -3,6 +3,7 @@ create table orders (
     created_at        timestamptz   not null    default now(),
     updated_at        timestamptz   not null    default now(),
     number            text          unique not null,
+    tracking_token    text          unique,
     customer_name     text          not null,
     type              text          not null check (type in ('dine_in', 'takeout', 'delivery')),
     table_number      integer,
@@ -11,9 +12,14 @@ create table orders (
     priority          integer       default 1,
     status            text          default 'received',
     processed_by      text,
-    completed_at      timestamptz
+    completed_at      timestamptz,
+    customer_phone    text,
+    customer_email    text
 );
 
+-- the live board replay and the queue counts behind each ETA filter on these
+create index orders_type_status_idx on orders (type, status);
+
 create table order_items (
     id          serial        primary key,
     created_at  timestamptz   not null    default now(),
@@ -23,6 +29,78 @@ create table order_items (
     price       decimal(8,2)  not null
 );
 
+create table notification_preferences (
+    order_id    integer       primary key references orders(id),
+    created_at  timestamptz   not null    default now(),
+    channels    text[]        not null    check (channels <@ array['email', 'sms']),
+    statuses    text[]        not null,
+    locale      text          not null    default 'en' check (locale in ('en', 'ru', 'kk'))
+);
+
+-- every notification notification-service tried to deliver, retried with backoff while pending
+create table notification_deliveries (
+    id               serial        primary key,
+    created_at       timestamptz   not null    default now(),
+    updated_at       timestamptz   not null    default now(),
+    order_number     text          not null,
+    order_status     text          not null,
+    channel          text          not null,
+    recipient        text,
+    payload          jsonb         not null,
+    status           text          not null    check (status in ('pending', 'sent', 'failed', 'skipped')),
+    attempts         integer       not null    default 0,
+    last_error       text,
+    next_attempt_at  timestamptz,
+    delivered_at     timestamptz
+);
+
+create index notification_deliveries_order_idx on notification_deliveries (order_number);
+create index notification_deliveries_due_idx on notification_deliveries (next_attempt_at) where status = 'pending';
+
+-- status events notification-service has handled, kept for a week to drop redeliveries
+create table notification_processed_events (
+    event_id      text          primary key,
+    processed_at  timestamptz   not null    default now()
+);
+
+-- the furthest status each order has been notified about
+create table notification_order_state (
+    order_number  text          primary key,
+    updated_at    timestamptz   not null    default now(),
+    last_status   text          not null
+);
+
+-- third-party endpoints that receive signed order and inventory events
+create table webhook_subscriptions (
+    id           serial        primary key,
+    created_at   timestamptz   not null    default now(),
+    updated_at   timestamptz   not null    default now(),
+    url          text          not null,
+    event_types  text[]        not null    check (event_types <@ array['order.status_changed', 'inventory.low_stock']),
+    secret       text          not null,
+    active       boolean       not null    default true
+);
+
+-- one row per event per subscription, retried with backoff while pending
+create table webhook_deliveries (
+    id               serial        primary key,
+    created_at       timestamptz   not null    default now(),
+    updated_at       timestamptz   not null    default now(),
+    subscription_id  integer       not null    references webhook_subscriptions(id) on delete cascade,
+    event_id         text          not null,
+    event_type       text          not null,
+    payload          jsonb         not null,
+    status           text          not null    check (status in ('pending', 'sent', 'failed')),
+    attempts         integer       not null    default 0,
+    response_status  integer,
+    last_error       text,
+    next_attempt_at  timestamptz,
+    delivered_at     timestamptz,
+    unique (subscription_id, event_id)
+);
+
+create index webhook_deliveries_due_idx on webhook_deliveries (next_attempt_at) where status = 'pending';
+
 create table order_status_log (
     id          serial        primary key,
     created_at  timestamptz   not null    default now(),
@@ -33,12 +111,107 @@ create table order_status_log (
     notes       text
 );
 
+-- status changes made by order-service, written in the same transaction as the
+-- change and published to notifications_fanout by its relay
+create table status_outbox (
+    id               serial        primary key,
+    created_at       timestamptz   not null    default now(),
+    order_number     text          not null,
+    order_type       text          not null,
+    old_status       text          not null    default '',
+    new_status       text          not null,
+    correlation_id   text          not null    default '',
+    changed_at       timestamptz   not null,
+    attempts         integer       not null    default 0,
+    next_attempt_at  timestamptz   not null    default now(),
+    published_at     timestamptz
+);
+
+create index status_outbox_due_idx on status_outbox (next_attempt_at) where published_at is null;
+
 create table workers (
-    id                serial      primary key,
-    created_at        timestamptz not null    default now(),
-    name              text        unique not null,
-    type              text        not null,
-    status            text        default 'online',
-    last_seen         timestamptz default current_timestamp,
-    orders_processed  integer     default 0
-);
\ No newline at end of file
+    id                  serial      primary key,
+    created_at          timestamptz not null    default now(),
+    name                text        unique not null,
+    type                text        not null,
+    status              text        default 'online',
+    last_seen           timestamptz default current_timestamp,
+    orders_processed    integer     default 0,
+    heartbeat_interval  integer     not null    default 30
+);
+
+create table order_cook_metrics (
+    id                 serial         primary key,
+    created_at         timestamptz    not null    default now(),
+    order_id           integer        references orders(id),
+    worker_name        text           not null,
+    order_type         text           not null,
+    started_at         timestamptz    not null,
+    finished_at        timestamptz    not null,
+    estimated_seconds  numeric(10,3)  not null,
+    actual_seconds     numeric(10,3)  not null
+);
+
+create index order_cook_metrics_worker_finished_idx on order_cook_metrics (worker_name, finished_at);
+
+create table ingredients (
+    id                   serial         primary key,
+    created_at           timestamptz    not null    default now(),
+    updated_at           timestamptz    not null    default now(),
+    name                 text           unique not null,
+    unit                 text           not null,
+    stock_quantity       numeric(10,3)  not null    default 0 check (stock_quantity >= 0),
+    reserved_quantity    numeric(10,3)  not null    default 0 check (reserved_quantity >= 0),
+    low_stock_threshold  numeric(10,3)  not null    default 0
+);
+
+create table recipes (
+    menu_item      text           not null,
+    ingredient_id  integer        not null    references ingredients(id),
+    quantity       numeric(10,3)  not null    check (quantity > 0),
+    primary key (menu_item, ingredient_id)
+);
+
+create table inventory_reservations (
+    id             serial         primary key,
+    created_at     timestamptz    not null    default now(),
+    updated_at     timestamptz    not null    default now(),
+    order_id       integer        not null    references orders(id),
+    ingredient_id  integer        not null    references ingredients(id),
+    quantity       numeric(10,3)  not null,
+    status         text           not null    default 'reserved' check (status in ('reserved', 'consumed', 'released'))
+);
+
+create index inventory_reservations_order_idx on inventory_reservations (order_id);
+
+insert into ingredients (name, unit, stock_quantity, low_stock_threshold) values
+    ('dough',        'ball', 100,   20),
+    ('tomato_sauce', 'ml',   10000, 2000),
+    ('mozzarella',   'g',    15000, 3000),
+    ('pepperoni',    'g',    5000,  1000),
+    ('mushrooms',    'g',    4000,  800),
+    ('ham',          'g',    4000,  800),
+    ('pineapple',    'g',    3000,  600);
+
+-- menu items without a recipe are not inventory tracked
+insert into recipes (menu_item, ingredient_id, quantity)
+select r.menu_item, i.id, r.quantity
+from (values
+    ('Margherita', 'dough',        1),
+    ('Margherita', 'tomato_sauce', 80),
+    ('Margherita', 'mozzarella',   120),
+    ('Pepperoni',  'dough',        1),
+    ('Pepperoni',  'tomato_sauce', 80),
+    ('Pepperoni',  'mozzarella',   100),
+    ('Pepperoni',  'pepperoni',    60),
+    ('Funghi',     'dough',        1),
+    ('Funghi',     'tomato_sauce', 80),
+    ('Funghi',     'mozzarella',   100),
+    ('Funghi',     'mushrooms',    70),
+    ('Hawaiian',   'dough',        1),
+    ('Hawaiian',   'tomato_sauce', 80),
+    ('Hawaiian',   'mozzarella',   100),
+    ('Hawaiian',   'ham',          60),
+    ('Hawaiian',   'pineapple',    60)
+) as r(menu_item, ingredient, quantity)
+join ingredients i on i.name = r.ingredient;
@@ -0,0 +1,90 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+	"restaurant-system/services/kitchen-service/domain/ports"
+	"restaurant-system/services/kitchen-service/utils/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresInventoryRepo struct {
+	db     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresInventoryRepo(db *pgxpool.Pool, serviceName string) ports.InventoryRepository {
+	return &PostgresInventoryRepo{
+		db:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+func (r *PostgresInventoryRepo) ConsumeReservations(ctx context.Context, orderNumber string) ([]domain.LowStockAlert, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Списываем только активные резервы — повторная доставка заказа ничего не спишет дважды.
+	// RETURNING отдаёт новые значения, прежний остаток = новый + списанное.
+	query := `
+		WITH consumed AS (
+			UPDATE inventory_reservations ir
+			SET status = 'consumed', updated_at = now()
+			FROM orders o
+			WHERE ir.order_id = o.id
+			  AND o.number = $1
+			  AND ir.status = 'reserved'
+			RETURNING ir.ingredient_id, ir.quantity
+		), totals AS (
+			SELECT ingredient_id, sum(quantity) AS quantity
+			FROM consumed
+			GROUP BY ingredient_id
+		)
+		UPDATE ingredients i
+		SET stock_quantity = i.stock_quantity - t.quantity,
+		    reserved_quantity = i.reserved_quantity - t.quantity,
+		    updated_at = now()
+		FROM totals t
+		WHERE i.id = t.ingredient_id
+		RETURNING i.name, i.unit, i.stock_quantity, i.low_stock_threshold,
+		          i.stock_quantity + t.quantity > i.low_stock_threshold
+	`
+	rows, err := tx.Query(ctx, query, orderNumber)
+	if err != nil {
+		r.Logger.Error("inventory_consume", "failed to consume reservations", orderNumber, err)
+		return nil, fmt.Errorf("failed to consume reservations: %w", err)
+	}
+
+	var alerts []domain.LowStockAlert
+	for rows.Next() {
+		var alert domain.LowStockAlert
+		var wasAbove bool
+		if err := rows.Scan(&alert.Ingredient, &alert.Unit, &alert.StockQuantity, &alert.LowStockThreshold, &wasAbove); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan consumed ingredient: %w", err)
+		}
+		// сигналим только при пересечении порога
+		if wasAbove && alert.StockQuantity <= alert.LowStockThreshold {
+			alerts = append(alerts, alert)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to consume reservations: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.Logger.Error("db_commit", "failed to commit transaction", orderNumber, err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return alerts, nil
+}
@@ -23,11 +23,11 @@ func NewPostgresKitchenRepo(db *pgxpool.Pool, serviceName string) ports.KitchenO
 	}
 }
 
-func (r *PostgresKitchenRepo) UpdateOrderStatus(ctx context.Context, orderNumber string, status domain.OrderStatus, processedBy string) error {
+func (r *PostgresKitchenRepo) UpdateOrderStatus(ctx context.Context, orderNumber string, status domain.OrderStatus, processedBy string) (int64, error) {
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		r.Logger.Error("db_transaction", "failed to begin transaction", orderNumber, err)
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	// если tx == nil, Rollback сразу не вызовется, но безопасно - проверим при Commit
 	defer func() {
@@ -41,18 +41,20 @@ func (r *PostgresKitchenRepo) UpdateOrderStatus(ctx context.Context, orderNumber
 	err = tx.QueryRow(ctx, queryGet, orderNumber).Scan(&orderID, &currentStatus)
 	if err != nil {
 		r.Logger.Error("order_lookup", "failed to get order id", orderNumber, err)
-		return fmt.Errorf("failed to get order id: %w", err)
+		return 0, fmt.Errorf("failed to get order id: %w", err)
 	}
 
-	// 2) Идемпотентность: если статус уже такой — ничего не делаем
+	// Отменённый заказ не готовим
+	if currentStatus == string(domain.StatusCancelled) {
+		return 0, fmt.Errorf("%w: %s", domain.ErrOrderCancelled, orderNumber)
+	}
+
+	// 2) Повторная попытка (заказ вернулся в очередь и его взял воркер): статус не меняется,
+	// но попытку пишем в лог с пометкой, чтобы история показала retry
+	notes := fmt.Sprintf("status changed to %s by %s", status, processedBy)
 	if currentStatus == string(status) {
-		r.Logger.Info("status_idempotent", fmt.Sprintf("Order %s already in status %s", orderNumber, status), orderNumber)
-		// не логируем в status_log повторно
-		if err := tx.Commit(ctx); err != nil {
-			r.Logger.Error("db_commit", "failed to commit (idempotent)", orderNumber, err)
-			return fmt.Errorf("failed to commit (idempotent): %w", err)
-		}
-		return nil
+		r.Logger.Info("status_retry", fmt.Sprintf("Order %s already in status %s, retried by %s", orderNumber, status, processedBy), orderNumber)
+		notes = fmt.Sprintf("retry: %s again by %s", status, processedBy)
 	}
 
 	// 3) Обновляем заказ
@@ -67,26 +69,28 @@ func (r *PostgresKitchenRepo) UpdateOrderStatus(ctx context.Context, orderNumber
 	_, err = tx.Exec(ctx, queryUpdate, string(status), processedBy, orderID)
 	if err != nil {
 		r.Logger.Error("order_update", "failed to update order status", orderNumber, err)
-		return fmt.Errorf("failed to update order status: %w", err)
+		return 0, fmt.Errorf("failed to update order status: %w", err)
 	}
 
 	// 4) Вставляем лог статуса
 	queryLog := `
 		INSERT INTO order_status_log (order_id, status, changed_by, changed_at, notes)
 		VALUES ($1, $2, $3, now(), $4)
+		RETURNING id
 	`
-	_, err = tx.Exec(ctx, queryLog, orderID, string(status), processedBy, fmt.Sprintf("status changed to %s by %s", status, processedBy))
+	var logID int64
+	err = tx.QueryRow(ctx, queryLog, orderID, string(status), processedBy, notes).Scan(&logID)
 	if err != nil {
 		r.Logger.Error("status_log", "failed to insert status log", orderNumber, err)
-		return fmt.Errorf("failed to insert status log: %w", err)
+		return 0, fmt.Errorf("failed to insert status log: %w", err)
 	}
 
 	// 5) Commit
 	if err := tx.Commit(ctx); err != nil {
 		r.Logger.Error("db_commit", "failed to commit transaction", orderNumber, err)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	r.Logger.Info("order_status_updated", fmt.Sprintf("Order %s set to %s by %s", orderNumber, status, processedBy), orderNumber)
-	return nil
+	return logID, nil
 }
@@ -0,0 +1,50 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+	"restaurant-system/services/kitchen-service/domain/ports"
+	"restaurant-system/services/kitchen-service/utils/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresCookMetricsRepo struct {
+	db     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresCookMetricsRepo(db *pgxpool.Pool, serviceName string) ports.CookMetricsRepository {
+	return &PostgresCookMetricsRepo{
+		db:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+func (r *PostgresCookMetricsRepo) SaveCookMetric(ctx context.Context, metric domain.CookMetric) error {
+	query := `
+		INSERT INTO order_cook_metrics
+			(order_id, worker_name, order_type, started_at, finished_at, estimated_seconds, actual_seconds)
+		SELECT id, $2, $3, $4, $5, $6, $7
+		FROM orders
+		WHERE number = $1
+	`
+	tag, err := r.db.Exec(ctx, query,
+		metric.OrderNumber,
+		metric.WorkerName,
+		metric.OrderType,
+		metric.StartedAt,
+		metric.FinishedAt,
+		metric.Estimated.Seconds(),
+		metric.Actual.Seconds(),
+	)
+	if err != nil {
+		r.Logger.Error("cook_metric_insert", "failed to save cook metric", metric.OrderNumber, err)
+		return fmt.Errorf("failed to save cook metric: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to save cook metric: order %s not found", metric.OrderNumber)
+	}
+	return nil
+}
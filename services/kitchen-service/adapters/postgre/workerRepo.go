@@ -6,6 +6,7 @@ import (
 	domain "restaurant-system/services/kitchen-service/domain/models"
 	"restaurant-system/services/kitchen-service/domain/ports"
 	"restaurant-system/services/kitchen-service/utils/logger"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -24,8 +25,8 @@ func NewPostgresWorkerRepo(db *pgxpool.Pool, serviceName string) ports.WorkerRep
 
 func (r *PostgresWorkerRepo) Register(ctx context.Context, worker *domain.Worker) error {
 	query := `
-		INSERT INTO workers(name, type, status, orders_processed, last_seen, created_at)
-		VALUES($1,$2,$3,$4,$5,now())
+		INSERT INTO workers(name, type, status, orders_processed, last_seen, heartbeat_interval, created_at)
+		VALUES($1,$2,$3,$4,$5,$6,now())
 		RETURNING id
 	`
 	err := r.db.QueryRow(
@@ -36,6 +37,7 @@ func (r *PostgresWorkerRepo) Register(ctx context.Context, worker *domain.Worker
 		worker.Status,
 		worker.OrdersProcessed,
 		worker.LastSeen,
+		int(worker.HeartbeatInterval.Seconds()),
 	).Scan(&worker.ID)
 	if err != nil {
 		return fmt.Errorf("failed to register worker: %w", err)
@@ -47,11 +49,13 @@ func (r *PostgresWorkerRepo) Update(ctx context.Context, worker *domain.Worker)
 	_, err := r.db.Exec(
 		ctx,
 		`UPDATE workers
-		SET status = $1, orders_processed = $2, last_seen = $3
-		WHERE id = $4`,
+		SET type = $1, status = $2, orders_processed = $3, last_seen = $4, heartbeat_interval = $5
+		WHERE id = $6`,
+		worker.Type,
 		worker.Status,
 		worker.OrdersProcessed,
 		worker.LastSeen,
+		int(worker.HeartbeatInterval.Seconds()),
 		worker.ID,
 	)
 	if err != nil {
@@ -60,9 +64,47 @@ func (r *PostgresWorkerRepo) Update(ctx context.Context, worker *domain.Worker)
 	return nil
 }
 
+// Touch обновляет только last_seen
+func (r *PostgresWorkerRepo) Touch(ctx context.Context, name string, lastSeen time.Time) error {
+	return r.execOne(ctx, "touch worker", name,
+		`UPDATE workers SET last_seen = $2 WHERE name = $1`,
+		name, lastSeen)
+}
+
+// IncrementProcessed увеличивает счётчик в самой БД, без чтения старого значения
+func (r *PostgresWorkerRepo) IncrementProcessed(ctx context.Context, name string, lastSeen time.Time) error {
+	return r.execOne(ctx, "count processed order", name,
+		`UPDATE workers SET orders_processed = orders_processed + 1, last_seen = $2 WHERE name = $1`,
+		name, lastSeen)
+}
+
+func (r *PostgresWorkerRepo) SetStatus(ctx context.Context, name string, status domain.WorkerStatus, lastSeen time.Time) error {
+	return r.execOne(ctx, "set worker status", name,
+		`UPDATE workers SET status = $2, last_seen = $3 WHERE name = $1`,
+		name, string(status), lastSeen)
+}
+
+func (r *PostgresWorkerRepo) SetType(ctx context.Context, name, workerType string) error {
+	return r.execOne(ctx, "set worker type", name,
+		`UPDATE workers SET type = $2 WHERE name = $1`,
+		name, workerType)
+}
+
+// execOne выполняет UPDATE одной строки воркера; ни одной строки — воркер не найден
+func (r *PostgresWorkerRepo) execOne(ctx context.Context, action, name, query string, args ...any) error {
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to %s: worker %s not found", action, name)
+	}
+	return nil
+}
+
 func (r *PostgresWorkerRepo) GetAll(ctx context.Context) ([]domain.Worker, error) {
 	rows, err := r.db.Query(ctx,
-		`SELECT id, name, type, status, orders_processed, last_seen, created_at
+		`SELECT id, name, type, status, orders_processed, last_seen, heartbeat_interval, created_at
 		 FROM workers ORDER BY created_at ASC`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query workers: %w", err)
@@ -72,6 +114,7 @@ func (r *PostgresWorkerRepo) GetAll(ctx context.Context) ([]domain.Worker, error
 	var workers []domain.Worker
 	for rows.Next() {
 		var worker domain.Worker
+		var heartbeatSeconds int
 		err := rows.Scan(
 			&worker.ID,
 			&worker.Name,
@@ -79,11 +122,13 @@ func (r *PostgresWorkerRepo) GetAll(ctx context.Context) ([]domain.Worker, error
 			&worker.Status,
 			&worker.OrdersProcessed,
 			&worker.LastSeen,
+			&heartbeatSeconds,
 			&worker.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan worker: %w", err)
 		}
+		worker.HeartbeatInterval = time.Duration(heartbeatSeconds) * time.Second
 		workers = append(workers, worker)
 	}
 	return workers, nil
@@ -91,11 +136,12 @@ func (r *PostgresWorkerRepo) GetAll(ctx context.Context) ([]domain.Worker, error
 
 func (r *PostgresWorkerRepo) GetByName(ctx context.Context, name string) (*domain.Worker, error) {
 	row := r.db.QueryRow(ctx,
-		`SELECT id, name, type, status, orders_processed, last_seen, created_at
+		`SELECT id, name, type, status, orders_processed, last_seen, heartbeat_interval, created_at
 		 FROM workers WHERE name = $1`,
 		name,
 	)
 	var worker domain.Worker
+	var heartbeatSeconds int
 	err := row.Scan(
 		&worker.ID,
 		&worker.Name,
@@ -103,10 +149,12 @@ func (r *PostgresWorkerRepo) GetByName(ctx context.Context, name string) (*domai
 		&worker.Status,
 		&worker.OrdersProcessed,
 		&worker.LastSeen,
+		&heartbeatSeconds,
 		&worker.CreatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get worker by name: %w", err)
 	}
+	worker.HeartbeatInterval = time.Duration(heartbeatSeconds) * time.Second
 	return &worker, nil
 }
@@ -2,6 +2,7 @@ package rabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"restaurant-system/services/kitchen-service/config"
 	"restaurant-system/services/kitchen-service/utils/logger"
@@ -45,6 +46,14 @@ func NewClient(rabbitConfig config.RabbitMQConfig, serviceName string) (*Client,
 	return &Client{conn: conn, channel: ch}, nil
 }
 
+// SetPrefetch задаёт сколько неподтверждённых сообщений может получить воркер
+func (c *Client) SetPrefetch(count int) error {
+	if err := c.channel.Qos(count, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+	return nil
+}
+
 func (c *Client) DeclareExchange(name, exchangeType string) error {
 	err := c.channel.ExchangeDeclare(
 		name,         // name
@@ -76,6 +85,43 @@ func (c *Client) DeclareQueue(queueName string) (amqp.Queue, error) {
 	return queue, nil
 }
 
+// DeclarePriorityQueue объявляет долговечную очередь, которая отдаёт сообщения
+// с большим Priority раньше; приоритеты выше maxPriority считаются равными ему
+func (c *Client) DeclarePriorityQueue(queueName string, maxPriority uint8) (amqp.Queue, error) {
+	queue, err := c.channel.QueueDeclare(
+		queueName,
+		true,  // durable
+		false, // auto-delete
+		false, // exclusive
+		false, // no-wait
+		amqp.Table{"x-max-priority": int32(maxPriority)},
+	)
+	if err != nil {
+		var amqpErr *amqp.Error
+		if errors.As(err, &amqpErr) && amqpErr.Code == amqp.PreconditionFailed {
+			return amqp.Queue{}, fmt.Errorf("queue %s already exists without x-max-priority %d; drain it and delete it with rabbitmqctl delete_queue %s: %w", queueName, maxPriority, queueName, err)
+		}
+		return amqp.Queue{}, fmt.Errorf("failed to declare queue %s: %w", queueName, err)
+	}
+	return queue, nil
+}
+
+// DeclareExclusiveQueue объявляет очередь, которая удаляется вместе с соединением
+func (c *Client) DeclareExclusiveQueue(queueName string) (amqp.Queue, error) {
+	queue, err := c.channel.QueueDeclare(
+		queueName,
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // args
+	)
+	if err != nil {
+		return amqp.Queue{}, fmt.Errorf("failed to declare exclusive queue %s: %w", queueName, err)
+	}
+	return queue, nil
+}
+
 func (c *Client) BindQueue(queueName, exchange, routingKey string) error {
 	err := c.channel.QueueBind(
 		queueName,
@@ -90,6 +136,38 @@ func (c *Client) BindQueue(queueName, exchange, routingKey string) error {
 	return nil
 }
 
+// RetireQueue снимает привязку устаревшей очереди и удаляет её, если она пуста.
+// Возвращает число сообщений, оставшихся в непустой очереди (тогда она не удаляется).
+// Работает на отдельном канале: отсутствие очереди брокер сообщает ошибкой 404,
+// которая закрывает канал, а основной канал должен остаться живым.
+func (c *Client) RetireQueue(queueName, exchange, routingKey string) (int, error) {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	queue, err := ch.QueueDeclarePassive(queueName, true, false, false, false, nil)
+	if err != nil {
+		var amqpErr *amqp.Error
+		if errors.As(err, &amqpErr) && amqpErr.Code == amqp.NotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to inspect queue %s: %w", queueName, err)
+	}
+
+	if err := ch.QueueUnbind(queueName, routingKey, exchange, nil); err != nil {
+		return 0, fmt.Errorf("failed to unbind queue %s from exchange %s: %w", queueName, exchange, err)
+	}
+	if queue.Messages > 0 {
+		return queue.Messages, nil
+	}
+	if _, err := ch.QueueDelete(queueName, false, true, false); err != nil {
+		return 0, fmt.Errorf("failed to delete queue %s: %w", queueName, err)
+	}
+	return 0, nil
+}
+
 func (c *Client) Publish(exchange, routingKey string, message []byte) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -123,6 +201,23 @@ func (c *Client) PublishWithPersistentDelivery(exchange, routingKey string, mess
 		})
 }
 
+// PublishReply отправляет ответ в очередь reply-to через default exchange
+func (c *Client) PublishReply(replyTo, correlationID string, message []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return c.channel.PublishWithContext(ctx,
+		"",      // default exchange
+		replyTo, // routing key = имя очереди
+		false,   // mandatory
+		false,   // immediate
+		amqp.Publishing{
+			ContentType:   "application/json",
+			CorrelationId: correlationID,
+			Body:          message,
+		})
+}
+
 func (c *Client) Consume(queueName, consumer string) (<-chan amqp.Delivery, error) {
 	msgs, err := c.channel.Consume(
 		queueName,
@@ -139,6 +234,14 @@ func (c *Client) Consume(queueName, consumer string) (<-chan amqp.Delivery, erro
 	return msgs, nil
 }
 
+// Cancel останавливает доставку потребителю; его канал сообщений закроется
+func (c *Client) Cancel(consumerTag string) error {
+	if err := c.channel.Cancel(consumerTag, false); err != nil {
+		return fmt.Errorf("failed to cancel consumer %s: %w", consumerTag, err)
+	}
+	return nil
+}
+
 func (c *Client) Close() {
 	if c.channel != nil {
 		c.channel.Close()
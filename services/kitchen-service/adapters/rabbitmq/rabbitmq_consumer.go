@@ -6,75 +6,238 @@ import (
 	"fmt"
 	domain "restaurant-system/services/kitchen-service/domain/models"
 	"restaurant-system/services/kitchen-service/utils/logger"
+	"restaurant-system/shared/events"
+	"slices"
+	"strings"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// Типы заказов; у каждого своя очередь kitchen_<type>_orders
+var orderTypes = []string{"dine_in", "takeout", "delivery"}
+
+// maxOrderPriority — наибольший приоритет заказа; очереди типов отдают
+// срочные заказы раньше, как и показывает позиция в очереди в tracking-service
+const maxOrderPriority = 10
+
+// legacyQueue — общая очередь, из которой кухня читала до очередей по типам
+const legacyQueue = "kitchen_orders"
+
 type KitchenConsumer struct {
-	client    *Client
-	logger    *logger.Logger
-	prefetch  int
-	orderType string
+	client   *Client
+	logger   *logger.Logger
+	prefetch int
+
+	mu         sync.Mutex
+	orderTypes []string
+	// очереди, из которых сейчас читаем, и теги их потребителей
+	consuming map[string]string
+	ctx       context.Context
+	out       chan domain.OrderMessage
+	// out закрывается, когда завершился последний forward
+	forwarders int
 }
 
-func NewKitchenConsumer(client *Client, prefetch int, orderType string) (*KitchenConsumer, error) {
+func NewKitchenConsumer(client *Client, prefetch int, orderTypes []string) (*KitchenConsumer, error) {
 	consumer := &KitchenConsumer{
-		client:    client,
-		logger:    logger.New("kitchen-consumer"),
-		prefetch:  prefetch,
-		orderType: orderType,
+		client:     client,
+		logger:     logger.New("kitchen-consumer"),
+		prefetch:   prefetch,
+		orderTypes: orderTypes,
+		consuming:  make(map[string]string),
+	}
+
+	// prefetch действует на каждого потребителя, т.е. на каждую очередь типа
+	if prefetch < 1 {
+		prefetch = 1
+	}
+	if err := client.SetPrefetch(prefetch); err != nil {
+		return nil, err
 	}
 
-	if err := consumer.setupQueue(); err != nil {
+	if err := consumer.setupQueues(); err != nil {
 		return nil, err
 	}
 
 	return consumer, nil
 }
 
-func (c *KitchenConsumer) setupQueue() error {
-	queue, err := c.client.DeclareQueue("kitchen_orders")
+// queueFor — очередь заказов одного типа
+func queueFor(orderType string) string {
+	return fmt.Sprintf("kitchen_%s_orders", orderType)
+}
+
+// setupQueues объявляет по приоритетной очереди на тип заказа с привязкой kitchen.<type>.*.
+// Воркер читает только очереди своих типов, поэтому заказ, который никто
+// не готовит, спокойно ждёт в своей очереди, а не ходит по кругу между воркерами.
+func (c *KitchenConsumer) setupQueues() error {
+	for _, orderType := range orderTypes {
+		queue, err := c.client.DeclarePriorityQueue(queueFor(orderType), maxOrderPriority)
+		if err != nil {
+			return err
+		}
+		if err := c.client.BindQueue(queue.Name, "orders_topic", fmt.Sprintf("kitchen.%s.*", orderType)); err != nil {
+			return err
+		}
+	}
+
+	// Старая общая очередь kitchen_orders осталась на брокерах, поднятых до
+	// перехода на очереди по типам, и продолжала бы копить заказы.
+	left, err := c.client.RetireQueue(legacyQueue, "orders_topic", "kitchen.*.*")
 	if err != nil {
 		return err
 	}
+	if left > 0 {
+		c.logger.Error("legacy_queue_not_empty",
+			fmt.Sprintf("Queue %s is unbound but still holds %d orders; move them to the per-type queues and delete it", legacyQueue, left),
+			"", nil)
+	}
+	return nil
+}
 
-	// строго один тип
-	routingKey := fmt.Sprintf("kitchen.%s.*", c.orderType)
-	if err := c.client.BindQueue(queue.Name, "orders_topic", routingKey); err != nil {
-		return err
+// queuesFor — очереди для набора типов; пустой набор означает все типы
+func queuesFor(types []string) []string {
+	if len(types) == 0 {
+		types = orderTypes
+	}
+	queues := make([]string, 0, len(types))
+	for _, orderType := range types {
+		queues = append(queues, queueFor(strings.TrimSpace(orderType)))
+	}
+	return queues
+}
+
+// Subscribe переключает воркера на очереди указанных типов:
+// от лишних отписывается, на новые подписывается
+func (c *KitchenConsumer) Subscribe(types []string) error {
+	for _, orderType := range types {
+		if !slices.Contains(orderTypes, strings.TrimSpace(orderType)) {
+			return fmt.Errorf("unknown order type %q", orderType)
+		}
 	}
 
-	return nil
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.orderTypes = types
+	if c.out == nil {
+		// ещё не начали потреблять — очереди подключит ConsumeOrders
+		return nil
+	}
+	return c.syncQueues()
 }
 
 func (c *KitchenConsumer) ConsumeOrders(ctx context.Context) (<-chan domain.OrderMessage, error) {
-	msgs, err := c.client.Consume("kitchen_orders", "kitchen-worker")
-	if err != nil {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ctx = ctx
+	c.out = make(chan domain.OrderMessage)
+	if err := c.syncQueues(); err != nil {
 		return nil, err
 	}
+	return c.out, nil
+}
+
+// syncQueues приводит набор потребителей к c.orderTypes; вызывается под c.mu.
+// Новые очереди подключаются раньше, чем снимаются старые, чтобы out не закрылся.
+func (c *KitchenConsumer) syncQueues() error {
+	wanted := queuesFor(c.orderTypes)
+
+	for _, queue := range wanted {
+		if _, ok := c.consuming[queue]; ok {
+			continue
+		}
+		tag := "kitchen-worker-" + queue
+		msgs, err := c.client.Consume(queue, tag)
+		if err != nil {
+			return err
+		}
+		c.consuming[queue] = tag
+		c.forwarders++
+		go c.forward(msgs)
+	}
+
+	for queue, tag := range c.consuming {
+		if slices.Contains(wanted, queue) {
+			continue
+		}
+		delete(c.consuming, queue)
+		if err := c.client.Cancel(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forward декодирует заказы из одной очереди в общий канал. Канал сообщений
+// закрывается после Cancel или при потере соединения; в последнем случае
+// завершаются все forward и KitchenService видит закрытый out.
+func (c *KitchenConsumer) forward(msgs <-chan amqp.Delivery) {
+	defer c.forwardDone()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case delivery, ok := <-msgs:
+			if !ok {
+				return
+			}
+
+			order, err := decodeOrder(delivery)
+			if err != nil {
+				c.logger.Error("message_decode_failed", "Failed to decode order message", "", err)
+				// битое сообщение не вернётся в очередь
+				_ = delivery.Nack(false, false)
+				continue
+			}
 
-	orderChan := make(chan domain.OrderMessage)
-	go func() {
-		defer close(orderChan)
-		for {
 			select {
-			case <-ctx.Done():
+			case c.out <- order:
+			case <-c.ctx.Done():
+				// заказ не взяли — пусть достанется другому воркеру
+				_ = delivery.Nack(false, true)
 				return
-			case delivery, ok := <-msgs:
-				if !ok {
-					return
-				}
-
-				var order domain.OrderMessage
-				if err := json.Unmarshal(delivery.Body, &order); err != nil {
-					c.logger.Error("message_decode_failed", "Failed to decode order message", "", err)
-					continue
-				}
-				order.Delivery = delivery
-				orderChan <- order
 			}
 		}
-	}()
+	}
+}
 
-	return orderChan, nil
+func (c *KitchenConsumer) forwardDone() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.forwarders--
+	if c.forwarders == 0 {
+		close(c.out)
+	}
+}
+
+func decodeOrder(delivery amqp.Delivery) (domain.OrderMessage, error) {
+	var event events.OrderCreated
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		return domain.OrderMessage{}, err
+	}
+	order := domain.OrderMessage{
+		OrderNumber:     event.OrderNumber,
+		CustomerName:    event.CustomerName,
+		OrderType:       event.OrderType,
+		TableNumber:     event.TableNumber,
+		DeliveryAddress: event.DeliveryAddress,
+		TotalAmount:     event.TotalAmount,
+		Priority:        event.Priority,
+		CorrelationID:   event.CorrelationID,
+		Delivery:        delivery,
+	}
+	for _, item := range event.Items {
+		order.Items = append(order.Items, domain.OrderItemRequest{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
+	return order, nil
 }
 
 func (c *KitchenConsumer) AckMessage(msg domain.OrderMessage) error {
@@ -0,0 +1,110 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+	"restaurant-system/services/kitchen-service/utils/logger"
+	"restaurant-system/shared/events"
+)
+
+type ControlConsumer struct {
+	client     *Client
+	logger     *logger.Logger
+	workerName string
+	queueName  string
+}
+
+func NewControlConsumer(client *Client, workerName string, serviceName string) (*ControlConsumer, error) {
+	consumer := &ControlConsumer{
+		client:     client,
+		logger:     logger.New(serviceName),
+		workerName: workerName,
+		queueName:  fmt.Sprintf("kitchen_control.%s", workerName),
+	}
+
+	// очередь живёт пока жив воркер, команды для остановленного воркера не копятся
+	if _, err := client.DeclareExclusiveQueue(consumer.queueName); err != nil {
+		return nil, err
+	}
+	routingKey := fmt.Sprintf("control.%s", workerName)
+	if err := client.BindQueue(consumer.queueName, "orders_topic", routingKey); err != nil {
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+func (c *ControlConsumer) ConsumeCommands(ctx context.Context) (<-chan domain.ControlCommand, error) {
+	msgs, err := c.client.Consume(c.queueName, "kitchen-control")
+	if err != nil {
+		return nil, err
+	}
+
+	commandChan := make(chan domain.ControlCommand)
+	go func() {
+		defer close(commandChan)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-msgs:
+				if !ok {
+					return
+				}
+
+				var event events.WorkerCommand
+				if err := json.Unmarshal(delivery.Body, &event); err != nil {
+					c.logger.Error("command_decode_failed", "Failed to decode control command", c.workerName, err)
+					_ = delivery.Nack(false, false)
+					continue
+				}
+				command := domain.ControlCommand{
+					Command:       domain.ControlCommandType(event.Command),
+					OrderTypes:    event.OrderTypes,
+					IssuedAt:      event.IssuedAt,
+					ReplyTo:       delivery.ReplyTo,
+					CorrelationID: delivery.CorrelationId,
+				}
+				_ = delivery.Ack(false)
+
+				select {
+				case commandChan <- command:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return commandChan, nil
+}
+
+func (c *ControlConsumer) Acknowledge(ctx context.Context, command domain.ControlCommand, ack domain.ControlAck) error {
+	if command.ReplyTo == "" {
+		return nil
+	}
+
+	envelope, err := events.NewEnvelope(events.TypeWorkerCommandAck, events.WorkerCommandAckVersion, command.CorrelationID, ack.Timestamp)
+	if err != nil {
+		return err
+	}
+	messageBytes, err := json.Marshal(events.WorkerCommandAck{
+		Envelope:   envelope,
+		WorkerName: ack.WorkerName,
+		Command:    string(ack.Command),
+		Accepted:   ack.Accepted,
+		State:      string(ack.State),
+		OrderTypes: ack.OrderTypes,
+		InFlight:   ack.InFlight,
+		Buffered:   ack.Buffered,
+		Error:      ack.Error,
+		Timestamp:  ack.Timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal control ack: %w", err)
+	}
+
+	return c.client.PublishReply(command.ReplyTo, command.CorrelationID, messageBytes)
+}
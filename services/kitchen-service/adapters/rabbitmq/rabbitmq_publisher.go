@@ -7,23 +7,44 @@ import (
 	domain "restaurant-system/services/kitchen-service/domain/models"
 	"restaurant-system/services/kitchen-service/domain/ports"
 	"restaurant-system/services/kitchen-service/utils/logger"
+	"restaurant-system/shared/events"
 	"time"
 )
 
 type NotificationPublisher struct {
 	client *Client
+	clock  ports.Clock
 	logger *logger.Logger
 }
 
-func NewNotificationPublisher(client *Client, serviceName string) ports.StatusPublisher {
+func NewNotificationPublisher(client *Client, clock ports.Clock, serviceName string) ports.StatusPublisher {
 	return &NotificationPublisher{
 		client: client,
+		clock:  clock,
 		logger: logger.New(serviceName),
 	}
 }
 
 func (p *NotificationPublisher) PublishStatusUpdate(ctx context.Context, event domain.OrderStatusUpdated) error {
-	messageBytes, err := json.Marshal(event)
+	envelope, err := events.NewEnvelope(events.TypeOrderStatusChanged, events.OrderStatusChangedVersion, event.CorrelationID, event.Timestamp)
+	if err != nil {
+		return err
+	}
+	// повторная публикация того же перехода получает тот же id, notification-service её отбросит
+	if event.StatusLogID != 0 {
+		envelope.EventID = events.TransitionEventID(event.OrderNumber, event.NewStatus, event.StatusLogID)
+	}
+
+	messageBytes, err := json.Marshal(events.OrderStatusChanged{
+		Envelope:            envelope,
+		OrderNumber:         event.OrderNumber,
+		OrderType:           event.OrderType,
+		OldStatus:           event.OldStatus,
+		NewStatus:           event.NewStatus,
+		ChangedBy:           event.ChangedBy,
+		Timestamp:           event.Timestamp,
+		EstimatedCompletion: event.EstimatedCompletion,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal status update: %w", err)
 	}
@@ -31,26 +52,56 @@ func (p *NotificationPublisher) PublishStatusUpdate(ctx context.Context, event d
 	return p.client.Publish("notifications_fanout", "", messageBytes)
 }
 
-func (p *NotificationPublisher) PublishCookingStarted(ctx context.Context, order domain.OrderMessage, workerName string) error {
+func (p *NotificationPublisher) PublishCookingStarted(ctx context.Context, order domain.OrderMessage, workerName string, statusLogID int64, estimatedCompletion time.Time) error {
 	event := domain.OrderStatusUpdated{
-		OrderNumber: order.OrderNumber,
-		OldStatus:   string(domain.StatusReceived),
-		NewStatus:   string(domain.StatusCooking),
-		ChangedBy:   workerName,
-		Timestamp:   time.Now(),
+		OrderNumber:         order.OrderNumber,
+		OrderType:           order.OrderType,
+		OldStatus:           string(domain.StatusReceived),
+		NewStatus:           string(domain.StatusCooking),
+		ChangedBy:           workerName,
+		Timestamp:           p.clock.Now(),
+		CorrelationID:       order.CorrelationID,
+		EstimatedCompletion: &estimatedCompletion,
+		StatusLogID:         statusLogID,
 	}
 
 	return p.PublishStatusUpdate(ctx, event)
 }
 
-func (p *NotificationPublisher) PublishOrderReady(ctx context.Context, order domain.OrderMessage, workerName string) error {
+func (p *NotificationPublisher) PublishOrderReady(ctx context.Context, order domain.OrderMessage, workerName string, statusLogID int64) error {
 	event := domain.OrderStatusUpdated{
-		OrderNumber: order.OrderNumber,
-		OldStatus:   string(domain.StatusCooking),
-		NewStatus:   string(domain.StatusReady),
-		ChangedBy:   workerName,
-		Timestamp:   time.Now(),
+		OrderNumber:   order.OrderNumber,
+		OrderType:     order.OrderType,
+		OldStatus:     string(domain.StatusCooking),
+		NewStatus:     string(domain.StatusReady),
+		ChangedBy:     workerName,
+		Timestamp:     p.clock.Now(),
+		CorrelationID: order.CorrelationID,
+		StatusLogID:   statusLogID,
 	}
 
 	return p.PublishStatusUpdate(ctx, event)
 }
+
+func (p *NotificationPublisher) PublishLowStock(ctx context.Context, alert domain.LowStockAlert, orderNumber string) error {
+	now := p.clock.Now()
+	envelope, err := events.NewEnvelope(events.TypeLowStock, events.LowStockVersion, "", now)
+	if err != nil {
+		return err
+	}
+
+	messageBytes, err := json.Marshal(events.LowStock{
+		Envelope:          envelope,
+		Ingredient:        alert.Ingredient,
+		Unit:              alert.Unit,
+		StockQuantity:     alert.StockQuantity,
+		LowStockThreshold: alert.LowStockThreshold,
+		OrderNumber:       orderNumber,
+		Timestamp:         now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal low stock event: %w", err)
+	}
+
+	return p.client.Publish("notifications_fanout", "", messageBytes)
+}
@@ -2,37 +2,75 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	domain "restaurant-system/services/kitchen-service/domain/models"
 	"restaurant-system/services/kitchen-service/domain/ports"
 	"restaurant-system/services/kitchen-service/utils/logger"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+var validOrderTypes = []string{"dine_in", "takeout", "delivery"}
+
 type KitchenService struct {
 	workerService    *WorkerService
 	orderConsumer    ports.MessageConsumer
+	controlConsumer  ports.ControlConsumer
 	statusPublisher  ports.StatusPublisher
 	kitchenOrderRepo ports.KitchenOrderRepository
+	metricsRepo      ports.CookMetricsRepository
+	inventoryRepo    ports.InventoryRepository
+	scheduler        Scheduler
+	clock            ports.Clock
 	workerName       string
-	logger           *logger.Logger
+	// сколько заказов готовится одновременно; остальные полученные ждут в планировщике
+	maxCooking int
+	logger     *logger.Logger
+
+	// Состояние воркера меняется только из цикла Start
+	state      domain.WorkerState
+	orderTypes []string
+	inFlight   sync.WaitGroup
+	inFlightN  atomic.Int32
 }
 
 func NewKitchenService(
 	workerService *WorkerService,
 	orderConsumer ports.MessageConsumer,
+	controlConsumer ports.ControlConsumer,
 	statusPublisher ports.StatusPublisher,
 	kitchenOrderRepo ports.KitchenOrderRepository,
+	metricsRepo ports.CookMetricsRepository,
+	inventoryRepo ports.InventoryRepository,
+	scheduler Scheduler,
+	clock ports.Clock,
 	workerName string,
+	orderTypes []string,
+	maxCooking int,
 	serviceName string,
 ) *KitchenService {
+	if maxCooking < 1 {
+		maxCooking = 1
+	}
 	return &KitchenService{
 		workerService:    workerService,
 		orderConsumer:    orderConsumer,
+		controlConsumer:  controlConsumer,
 		statusPublisher:  statusPublisher,
 		kitchenOrderRepo: kitchenOrderRepo,
+		metricsRepo:      metricsRepo,
+		inventoryRepo:    inventoryRepo,
+		scheduler:        scheduler,
+		clock:            clock,
 		workerName:       workerName,
+		maxCooking:       maxCooking,
 		logger:           logger.New(serviceName),
+		state:            domain.StateRunning,
+		orderTypes:       orderTypes,
 	}
 }
 
@@ -45,20 +83,157 @@ func (s *KitchenService) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to consume orders: %w", err)
 	}
 
+	commands, err := s.controlConsumer.ConsumeCommands(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to consume control commands: %w", err)
+	}
+
+	// сигнал об освободившемся месте у плиты
+	finished := make(chan struct{}, s.maxCooking)
+
 	for {
+		s.startNext(ctx, finished)
+
+		if s.state == domain.StateDraining && s.inFlightN.Load() == 0 {
+			s.logger.Info("service_drained", "All in-flight orders finished, stopping kitchen service", s.workerName)
+			return nil
+		}
+
+		// на паузе и при drain новые заказы не забираем
+		incoming := messages
+		if s.state != domain.StateRunning {
+			incoming = nil
+		}
+
 		select {
 		case <-ctx.Done():
 			s.logger.Info("service_stopping", "Stopping kitchen service", s.workerName)
+			s.requeueBuffered()
+			s.inFlight.Wait()
 			return nil
-		case msg, ok := <-messages:
+		case <-finished:
+		case cmd, ok := <-commands:
+			if !ok {
+				return fmt.Errorf("control channel closed")
+			}
+			s.handleCommand(ctx, cmd)
+		case msg, ok := <-incoming:
 			if !ok {
 				return fmt.Errorf("orders channel closed")
 			}
-			go s.processOrder(ctx, msg)
+			if !s.acceptsOrderType(msg.OrderType) {
+				// заказ успел прийти до смены типов — возвращаем в очередь его типа,
+				// которую этот воркер уже не читает
+				_ = s.orderConsumer.NackMessage(msg, true)
+				continue
+			}
+			msg.ReceivedAt = s.clock.Now()
+			s.scheduler.Push(msg)
 		}
 	}
 }
 
+// startNext отдаёт на готовку следующий заказ, выбранный планировщиком
+func (s *KitchenService) startNext(ctx context.Context, finished chan<- struct{}) {
+	if s.state != domain.StateRunning || int(s.inFlightN.Load()) >= s.maxCooking {
+		return
+	}
+	msg, ok := s.scheduler.Pop()
+	if !ok {
+		return
+	}
+
+	s.inFlight.Add(1)
+	s.inFlightN.Add(1)
+	go func() {
+		defer func() {
+			s.inFlightN.Add(-1)
+			s.inFlight.Done()
+			finished <- struct{}{}
+		}()
+		s.processOrder(ctx, msg)
+	}()
+}
+
+// requeueBuffered возвращает в очередь заказы, которые ещё не начали готовить
+func (s *KitchenService) requeueBuffered() {
+	for _, msg := range s.scheduler.Drain() {
+		_ = s.orderConsumer.NackMessage(msg, true)
+	}
+}
+
+func (s *KitchenService) acceptsOrderType(orderType string) bool {
+	return len(s.orderTypes) == 0 || slices.Contains(s.orderTypes, orderType)
+}
+
+// handleCommand применяет команду управления и отправляет подтверждение
+func (s *KitchenService) handleCommand(ctx context.Context, cmd domain.ControlCommand) {
+	requestID := fmt.Sprintf("control_%s", s.workerName)
+	s.logger.Info("control_command_received", fmt.Sprintf("Received control command %s", cmd.Command), requestID)
+
+	err := s.applyCommand(ctx, cmd)
+
+	ack := domain.ControlAck{
+		WorkerName: s.workerName,
+		Command:    cmd.Command,
+		Accepted:   err == nil,
+		State:      s.state,
+		OrderTypes: s.orderTypes,
+		InFlight:   int(s.inFlightN.Load()),
+		Buffered:   s.scheduler.Len(),
+		Timestamp:  s.clock.Now(),
+	}
+	if err != nil {
+		ack.Error = err.Error()
+		s.logger.Error("control_command_rejected", fmt.Sprintf("Control command %s rejected", cmd.Command), requestID, err)
+	}
+
+	if err := s.controlConsumer.Acknowledge(ctx, cmd, ack); err != nil {
+		s.logger.Error("control_ack_failed", "Failed to acknowledge control command", requestID, err)
+	}
+}
+
+func (s *KitchenService) applyCommand(ctx context.Context, cmd domain.ControlCommand) error {
+	if s.state == domain.StateDraining {
+		return fmt.Errorf("worker is draining")
+	}
+
+	switch cmd.Command {
+	case domain.CommandPause:
+		s.state = domain.StatePaused
+	case domain.CommandResume:
+		s.state = domain.StateRunning
+	case domain.CommandDrain:
+		s.state = domain.StateDraining
+		s.requeueBuffered()
+	case domain.CommandSetOrderTypes:
+		for _, orderType := range cmd.OrderTypes {
+			if !slices.Contains(validOrderTypes, orderType) {
+				return fmt.Errorf("invalid order type %q", orderType)
+			}
+		}
+		if err := s.orderConsumer.Subscribe(cmd.OrderTypes); err != nil {
+			return fmt.Errorf("failed to subscribe to order types: %w", err)
+		}
+		if err := s.workerService.UpdateWorkerType(ctx, s.workerName, strings.Join(cmd.OrderTypes, ",")); err != nil {
+			return fmt.Errorf("failed to save worker type: %w", err)
+		}
+		s.orderTypes = cmd.OrderTypes
+
+		// из буфера убираем заказы, которые больше не наши
+		for _, msg := range s.scheduler.Drain() {
+			if s.acceptsOrderType(msg.OrderType) {
+				s.scheduler.Push(msg)
+			} else {
+				_ = s.orderConsumer.NackMessage(msg, true)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s", domain.ErrUnknownCommand, cmd.Command)
+	}
+	return nil
+}
+
 func (s *KitchenService) processOrder(ctx context.Context, msg domain.OrderMessage) {
 	orderNumber := msg.OrderNumber
 	requestID := fmt.Sprintf("order_%s", orderNumber)
@@ -73,38 +248,55 @@ func (s *KitchenService) processOrder(ctx context.Context, msg domain.OrderMessa
 	s.logger.Info("order_received", fmt.Sprintf("Processing order %s", orderNumber), requestID)
 
 	// cooking started
-	if err := s.kitchenOrderRepo.UpdateOrderStatus(ctx, msg.OrderNumber, domain.StatusCooking, s.workerName); err != nil {
+	cookingLogID, err := s.kitchenOrderRepo.UpdateOrderStatus(ctx, msg.OrderNumber, domain.StatusCooking, s.workerName)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderCancelled) {
+			// заказ отменили до начала готовки — просто убираем из очереди
+			s.logger.Info("order_skipped", fmt.Sprintf("Order %s was cancelled, skipping", orderNumber), requestID)
+			_ = s.orderConsumer.AckMessage(msg)
+			return
+		}
 		s.logger.Error("update_status_failed", "Failed to update cooking status", requestID, err)
 		_ = s.orderConsumer.NackMessage(msg, true)
 		return
 	}
-	if err := s.statusPublisher.PublishCookingStarted(ctx, msg, s.workerName); err != nil {
+	// оценка готовности в реальном времени, её увидят клиенты
+	estimatedCompletion := s.clock.Now().Add(time.Duration(float64(msg.CookingTime()) / s.clock.Scale()))
+	if err := s.statusPublisher.PublishCookingStarted(ctx, msg, s.workerName, cookingLogID, estimatedCompletion); err != nil {
 		s.logger.Error("event_publish_failed", "Failed to publish cooking event", requestID, err)
 	}
 
-	// ограничиваем время готовки
-	cookingCtx, cancel := context.WithTimeout(ctx, msg.CookingTime())
-	defer cancel()
+	// списываем ингредиенты со склада
+	alerts, err := s.inventoryRepo.ConsumeReservations(ctx, orderNumber)
+	if err != nil {
+		s.logger.Error("inventory_consume_failed", "Failed to consume ingredient reservations", requestID, err)
+	}
+	for _, alert := range alerts {
+		if err := s.statusPublisher.PublishLowStock(ctx, alert, orderNumber); err != nil {
+			s.logger.Error("event_publish_failed", "Failed to publish low stock event", requestID, err)
+		}
+	}
 
+	// готовим; при остановке воркера возвращаем заказ в очередь
+	startedAt := s.clock.Now()
 	select {
-	case <-cookingCtx.Done():
-		if cookingCtx.Err() == context.DeadlineExceeded {
-			s.logger.Error("cooking_timeout", "Cooking time exceeded", requestID, cookingCtx.Err())
-			_ = s.orderConsumer.NackMessage(msg, true)
-			return
-		}
-	case <-time.After(msg.CookingTime()):
+	case <-ctx.Done():
+		s.logger.Info("cooking_interrupted", "Worker stopping, order returned to queue", requestID)
+		_ = s.orderConsumer.NackMessage(msg, true)
+		return
+	case <-s.clock.After(msg.CookingTime()):
 		// готово
 	}
 
 	// обновляем статус → ready
-	if err := s.kitchenOrderRepo.UpdateOrderStatus(ctx, orderNumber, domain.StatusReady, s.workerName); err != nil {
+	readyLogID, err := s.kitchenOrderRepo.UpdateOrderStatus(ctx, orderNumber, domain.StatusReady, s.workerName)
+	if err != nil {
 		s.logger.Error("status_update_failed", "Failed to update order to ready", requestID, err)
 		_ = s.orderConsumer.NackMessage(msg, true)
 		return
 	}
 
-	if err := s.statusPublisher.PublishOrderReady(ctx, msg, s.workerName); err != nil {
+	if err := s.statusPublisher.PublishOrderReady(ctx, msg, s.workerName, readyLogID); err != nil {
 		s.logger.Error("event_publish_failed", "Failed to publish ready event", requestID, err)
 	}
 
@@ -113,6 +305,20 @@ func (s *KitchenService) processOrder(ctx context.Context, msg domain.OrderMessa
 		s.logger.Error("worker_update_failed", "Failed to update worker stats", requestID, err)
 	}
 
+	// сохраняем замер готовки: отметки времени настенные, длительности симулированные
+	metric := domain.CookMetric{
+		OrderNumber: orderNumber,
+		WorkerName:  s.workerName,
+		OrderType:   msg.OrderType,
+		StartedAt:   startedAt,
+		FinishedAt:  s.clock.Now(),
+		Estimated:   msg.CookingTime(),
+		Actual:      s.clock.Since(startedAt),
+	}
+	if err := s.metricsRepo.SaveCookMetric(ctx, metric); err != nil {
+		s.logger.Error("metric_save_failed", "Failed to save cook metric", requestID, err)
+	}
+
 	// подтверждаем сообщение
 	if err := s.orderConsumer.AckMessage(msg); err != nil {
 		s.logger.Error("ack_failed", "Failed to ack message", requestID, err)
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	domain "restaurant-system/services/kitchen-service/domain/models"
+	"restaurant-system/services/kitchen-service/domain/ports"
+)
+
+type fakeConsumer struct {
+	ports.MessageConsumer
+	orders chan domain.OrderMessage
+}
+
+func (f *fakeConsumer) ConsumeOrders(ctx context.Context) (<-chan domain.OrderMessage, error) {
+	return f.orders, nil
+}
+
+func (f *fakeConsumer) AckMessage(domain.OrderMessage) error        { return nil }
+func (f *fakeConsumer) NackMessage(domain.OrderMessage, bool) error { return nil }
+
+type fakeControl struct {
+	ports.ControlConsumer
+}
+
+func (fakeControl) ConsumeCommands(ctx context.Context) (<-chan domain.ControlCommand, error) {
+	return make(chan domain.ControlCommand), nil
+}
+
+type fakeStatusPublisher struct {
+	ports.StatusPublisher
+}
+
+func (fakeStatusPublisher) PublishCookingStarted(context.Context, domain.OrderMessage, string, int64, time.Time) error {
+	return nil
+}
+
+func (fakeStatusPublisher) PublishOrderReady(context.Context, domain.OrderMessage, string, int64) error {
+	return nil
+}
+
+// fakeKitchenRepo запоминает, в каком порядке заказы начали готовить
+type fakeKitchenRepo struct {
+	mu      sync.Mutex
+	started []string
+}
+
+func (f *fakeKitchenRepo) UpdateOrderStatus(ctx context.Context, orderNumber string, status domain.OrderStatus, processedBy string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if status == domain.StatusCooking {
+		f.started = append(f.started, orderNumber)
+	}
+	return 1, nil
+}
+
+func (f *fakeKitchenRepo) startedOrders() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.started)
+}
+
+type fakeInventory struct{}
+
+func (fakeInventory) ConsumeReservations(context.Context, string) ([]domain.LowStockAlert, error) {
+	return nil, nil
+}
+
+type fakeMetrics struct{}
+
+func (fakeMetrics) SaveCookMetric(context.Context, domain.CookMetric) error { return nil }
+
+type fakeWorkerRepo struct {
+	ports.WorkerRepository
+}
+
+func (fakeWorkerRepo) IncrementProcessed(context.Context, string, time.Time) error { return nil }
+
+// stepClock отдаёт тесту каждый таймер готовки, чтобы тот сам решал, когда заказ готов
+type stepClock struct {
+	timers chan chan time.Time
+}
+
+func (c *stepClock) Now() time.Time                { return time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC) }
+func (c *stepClock) Since(time.Time) time.Duration { return 0 }
+func (c *stepClock) Scale() float64                { return 1 }
+
+func (c *stepClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.timers <- ch
+	return ch
+}
+
+// При заполненном буфере prefetch и одной плите следующий заказ выбирает
+// планировщик, а не порядок получения
+func TestKitchenServiceSchedulesFullBuffer(t *testing.T) {
+	consumer := &fakeConsumer{orders: make(chan domain.OrderMessage, 3)}
+	consumer.orders <- domain.OrderMessage{OrderNumber: "A", OrderType: "takeout", Priority: 1}
+	consumer.orders <- domain.OrderMessage{OrderNumber: "B", OrderType: "takeout", Priority: 1}
+	consumer.orders <- domain.OrderMessage{OrderNumber: "C", OrderType: "takeout", Priority: 10}
+
+	repo := &fakeKitchenRepo{}
+	clk := &stepClock{timers: make(chan chan time.Time)}
+	workers := NewWorkerService(fakeWorkerRepo{}, clk, "test")
+	kitchen := NewKitchenService(workers, consumer, fakeControl{}, fakeStatusPublisher{}, repo, fakeMetrics{}, fakeInventory{},
+		NewPriorityScheduler(), clk, "chef", nil, 1, "test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- kitchen.Start(ctx) }()
+
+	// A приходит первым и сразу занимает единственную плиту
+	timer := <-clk.timers
+	waitFor(t, func() bool { return len(consumer.orders) == 0 })
+	timer <- time.Time{}
+
+	// B и C ждут в буфере; C срочнее
+	timer = <-clk.timers
+	timer <- time.Time{}
+	<-clk.timers
+
+	if got, want := repo.startedOrders(), []string{"A", "C", "B"}; !slices.Equal(got, want) {
+		t.Errorf("cooking order = %v, want %v", got, want)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
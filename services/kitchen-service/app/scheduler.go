@@ -0,0 +1,139 @@
+package app
+
+import (
+	"container/heap"
+	"fmt"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+)
+
+// Политики выбора следующего заказа из буфера воркера
+const (
+	ScheduleFIFO     = "fifo"
+	SchedulePriority = "priority"
+	ScheduleShortest = "shortest"
+	ScheduleEDF      = "edf"
+)
+
+// Scheduler решает, какой из полученных (prefetch) заказов готовить следующим
+type Scheduler interface {
+	Push(order domain.OrderMessage)
+	Pop() (domain.OrderMessage, bool)
+	Len() int
+	// Drain забирает все заказы из буфера, например чтобы вернуть их в очередь
+	Drain() []domain.OrderMessage
+}
+
+func NewScheduler(policy string) (Scheduler, error) {
+	switch policy {
+	case "", ScheduleFIFO:
+		return NewFIFOScheduler(), nil
+	case SchedulePriority:
+		return NewPriorityScheduler(), nil
+	case ScheduleShortest:
+		return NewShortestCookScheduler(), nil
+	case ScheduleEDF:
+		return NewEDFScheduler(), nil
+	default:
+		return nil, fmt.Errorf("unknown schedule %q, expected one of: fifo, priority, shortest, edf", policy)
+	}
+}
+
+// NewFIFOScheduler — в порядке получения
+func NewFIFOScheduler() Scheduler {
+	return newHeapScheduler(func(a, b *scheduledOrder) bool {
+		return a.seq < b.seq
+	})
+}
+
+// NewPriorityScheduler — сначала больший priority, при равенстве — в порядке получения
+func NewPriorityScheduler() Scheduler {
+	return newHeapScheduler(func(a, b *scheduledOrder) bool {
+		if a.order.Priority != b.order.Priority {
+			return a.order.Priority > b.order.Priority
+		}
+		return a.seq < b.seq
+	})
+}
+
+// NewShortestCookScheduler — сначала заказы с меньшим временем готовки
+func NewShortestCookScheduler() Scheduler {
+	return newHeapScheduler(func(a, b *scheduledOrder) bool {
+		if a.order.CookingTime() != b.order.CookingTime() {
+			return a.order.CookingTime() < b.order.CookingTime()
+		}
+		return a.seq < b.seq
+	})
+}
+
+// NewEDFScheduler — сначала заказ с самым ранним Deadline
+func NewEDFScheduler() Scheduler {
+	return newHeapScheduler(func(a, b *scheduledOrder) bool {
+		da, db := a.order.Deadline(), b.order.Deadline()
+		if !da.Equal(db) {
+			return da.Before(db)
+		}
+		return a.seq < b.seq
+	})
+}
+
+type scheduledOrder struct {
+	order domain.OrderMessage
+	seq   uint64
+}
+
+type heapScheduler struct {
+	items orderHeap
+	next  uint64
+}
+
+func newHeapScheduler(less func(a, b *scheduledOrder) bool) *heapScheduler {
+	return &heapScheduler{items: orderHeap{less: less}}
+}
+
+func (s *heapScheduler) Push(order domain.OrderMessage) {
+	heap.Push(&s.items, &scheduledOrder{order: order, seq: s.next})
+	s.next++
+}
+
+func (s *heapScheduler) Pop() (domain.OrderMessage, bool) {
+	if s.items.Len() == 0 {
+		return domain.OrderMessage{}, false
+	}
+	item := heap.Pop(&s.items).(*scheduledOrder)
+	return item.order, true
+}
+
+func (s *heapScheduler) Len() int {
+	return s.items.Len()
+}
+
+func (s *heapScheduler) Drain() []domain.OrderMessage {
+	var orders []domain.OrderMessage
+	for s.items.Len() > 0 {
+		orders = append(orders, heap.Pop(&s.items).(*scheduledOrder).order)
+	}
+	return orders
+}
+
+// orderHeap реализует heap.Interface
+type orderHeap struct {
+	entries []*scheduledOrder
+	less    func(a, b *scheduledOrder) bool
+}
+
+func (h orderHeap) Len() int           { return len(h.entries) }
+func (h orderHeap) Less(i, j int) bool { return h.less(h.entries[i], h.entries[j]) }
+func (h orderHeap) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *orderHeap) Push(x any) {
+	h.entries = append(h.entries, x.(*scheduledOrder))
+}
+
+func (h *orderHeap) Pop() any {
+	old := h.entries
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	h.entries = old[:n-1]
+	return item
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	domain "restaurant-system/services/kitchen-service/domain/models"
+)
+
+func order(number, orderType string, priority int, receivedAt time.Time) domain.OrderMessage {
+	return domain.OrderMessage{
+		OrderNumber: number,
+		OrderType:   orderType,
+		Priority:    priority,
+		ReceivedAt:  receivedAt,
+	}
+}
+
+func numbers(orders []domain.OrderMessage) []string {
+	var result []string
+	for _, o := range orders {
+		result = append(result, o.OrderNumber)
+	}
+	return result
+}
+
+func TestSchedulerPolicies(t *testing.T) {
+	base := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	// в порядке получения
+	buffered := []domain.OrderMessage{
+		order("A", "delivery", 1, base),                      // дедлайн +5m12s
+		order("B", "dine_in", 5, base.Add(10*time.Second)),   // дедлайн +3m18s
+		order("C", "takeout", 10, base.Add(20*time.Second)),  // дедлайн +1m30s
+		order("D", "dine_in", 1, base.Add(30*time.Second)),   // дедлайн +5m38s
+		order("E", "delivery", 10, base.Add(40*time.Second)), // дедлайн +1m52s
+	}
+
+	tests := []struct {
+		policy string
+		want   []string
+	}{
+		{policy: "", want: []string{"A", "B", "C", "D", "E"}},
+		{policy: ScheduleFIFO, want: []string{"A", "B", "C", "D", "E"}},
+		// приоритет, при равенстве — порядок получения
+		{policy: SchedulePriority, want: []string{"C", "E", "B", "A", "D"}},
+		// dine_in 8s < takeout 10s < delivery 12s
+		{policy: ScheduleShortest, want: []string{"B", "D", "C", "A", "E"}},
+		{policy: ScheduleEDF, want: []string{"C", "E", "B", "A", "D"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("pop/"+tt.policy, func(t *testing.T) {
+			s, err := NewScheduler(tt.policy)
+			if err != nil {
+				t.Fatalf("NewScheduler(%q): %v", tt.policy, err)
+			}
+			for _, o := range buffered {
+				s.Push(o)
+			}
+			if s.Len() != len(buffered) {
+				t.Fatalf("Len() = %d, want %d", s.Len(), len(buffered))
+			}
+
+			var got []string
+			for {
+				o, ok := s.Pop()
+				if !ok {
+					break
+				}
+				got = append(got, o.OrderNumber)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Pop order = %v, want %v", got, tt.want)
+			}
+			if s.Len() != 0 {
+				t.Errorf("Len() after popping everything = %d", s.Len())
+			}
+		})
+
+		t.Run("drain/"+tt.policy, func(t *testing.T) {
+			s, _ := NewScheduler(tt.policy)
+			for _, o := range buffered {
+				s.Push(o)
+			}
+			if got := numbers(s.Drain()); !slices.Equal(got, tt.want) {
+				t.Errorf("Drain() = %v, want %v", got, tt.want)
+			}
+			if _, ok := s.Pop(); ok {
+				t.Error("Pop() after Drain returned an order")
+			}
+		})
+	}
+}
+
+func TestSchedulerInterleavedPushPop(t *testing.T) {
+	base := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	s := NewPriorityScheduler()
+
+	s.Push(order("low", "takeout", 1, base))
+	s.Push(order("mid", "takeout", 5, base))
+	if o, _ := s.Pop(); o.OrderNumber != "mid" {
+		t.Fatalf("first Pop = %s, want mid", o.OrderNumber)
+	}
+
+	// новый срочный заказ обгоняет уже ждущий
+	s.Push(order("high", "takeout", 10, base))
+	if got := numbers(s.Drain()); !slices.Equal(got, []string{"high", "low"}) {
+		t.Errorf("Drain() = %v, want [high low]", got)
+	}
+}
+
+func TestSchedulerEmpty(t *testing.T) {
+	s := NewFIFOScheduler()
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop() on empty scheduler returned an order")
+	}
+	if got := s.Drain(); len(got) != 0 {
+		t.Errorf("Drain() on empty scheduler = %v", got)
+	}
+}
+
+func TestNewSchedulerUnknownPolicy(t *testing.T) {
+	if _, err := NewScheduler("lifo"); err == nil {
+		t.Error("NewScheduler(\"lifo\") returned no error")
+	}
+}
@@ -13,24 +13,27 @@ import (
 
 type WorkerService struct {
 	repo   ports.WorkerRepository
+	clock  ports.Clock
 	Logger *logger.Logger
 }
 
-func NewWorkerService(repo ports.WorkerRepository, serviceName string) *WorkerService {
+func NewWorkerService(repo ports.WorkerRepository, clock ports.Clock, serviceName string) *WorkerService {
 	return &WorkerService{
 		repo:   repo,
+		clock:  clock,
 		Logger: logger.New(serviceName),
 	}
 }
 
-func (s *WorkerService) RegisterWorker(ctx context.Context, name, workerType string) error {
+func (s *WorkerService) RegisterWorker(ctx context.Context, name, workerType string, heartbeatInterval time.Duration) error {
 	worker := &domain.Worker{
-		Name:            name,
-		Type:            workerType,
-		Status:          domain.WorkerOffline,
-		OrdersProcessed: 0,
-		LastSeen:        time.Now(),
-		CreatedAt:       time.Now(),
+		Name:              name,
+		Type:              workerType,
+		Status:            domain.WorkerOnline,
+		OrdersProcessed:   0,
+		LastSeen:          s.clock.Now(),
+		CreatedAt:         s.clock.Now(),
+		HeartbeatInterval: heartbeatInterval,
 	}
 	if err := s.repo.Register(ctx, worker); err != nil {
 		return fmt.Errorf("failed to register worker: %w", err)
@@ -41,14 +44,11 @@ func (s *WorkerService) RegisterWorker(ctx context.Context, name, workerType str
 // функция в WorkerService
 // Добавляем методы для graceful shutdown
 func (s *WorkerService) StartHeartbeat(ctx context.Context, workerName string, interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
+		case <-s.clock.After(interval):
 			if err := s.Heartbeat(ctx, workerName); err != nil {
 				return
 			}
@@ -57,16 +57,10 @@ func (s *WorkerService) StartHeartbeat(ctx context.Context, workerName string, i
 }
 
 func (s *WorkerService) SetWorkerOffline(ctx context.Context, workerName string) error {
-	worker, err := s.repo.GetByName(ctx, workerName)
-	if err != nil {
-		return fmt.Errorf("worker not found: %w", err)
-	}
-
-	if err := worker.GoOffline(); err != nil {
+	if err := s.repo.SetStatus(ctx, workerName, domain.WorkerOffline, s.clock.Now()); err != nil {
 		return fmt.Errorf("failed to set offline: %w", err)
 	}
-
-	return s.repo.Update(ctx, worker)
+	return nil
 }
 
 func (s *WorkerService) SetOfflineForAllWorkers(ctx context.Context) error {
@@ -78,7 +72,7 @@ func (s *WorkerService) SetOfflineForAllWorkers(ctx context.Context) error {
 	for _, worker := range workers {
 		if worker.Status == domain.WorkerOnline {
 			worker.Status = domain.WorkerOffline
-			worker.LastSeen = time.Now()
+			worker.LastSeen = s.clock.Now()
 			if err := s.repo.Update(ctx, &worker); err != nil {
 				return err
 			}
@@ -88,7 +82,7 @@ func (s *WorkerService) SetOfflineForAllWorkers(ctx context.Context) error {
 	return nil
 }
 
-func (s *WorkerService) EnsureRegistered(ctx context.Context, name, workerType string) error {
+func (s *WorkerService) EnsureRegistered(ctx context.Context, name, workerType string, heartbeatInterval time.Duration) error {
 	existing, err := s.repo.GetByName(ctx, name)
 	if err != nil && err != pgx.ErrNoRows { // адаптировать под используемый драйвер
 		return err
@@ -99,33 +93,39 @@ func (s *WorkerService) EnsureRegistered(ctx context.Context, name, workerType s
 		}
 		// обновляем запись
 		existing.Type = workerType
-		existing.LastSeen = time.Now()
+		existing.HeartbeatInterval = heartbeatInterval
+		existing.LastSeen = s.clock.Now()
 		existing.Status = domain.WorkerOnline
 		return s.repo.Update(ctx, existing)
 	}
 	// создаём новую запись
-	err = s.RegisterWorker(ctx, name, workerType)
+	err = s.RegisterWorker(ctx, name, workerType, heartbeatInterval)
 	return err
 }
 
 func (s *WorkerService) Heartbeat(ctx context.Context, workerName string) error {
-	worker, err := s.GetWorkerByName(ctx, workerName)
-	if err != nil {
-		s.Logger.Error("worker_not_found", "Failed to Heartbeat", "", err)
+	if err := s.repo.Touch(ctx, workerName, s.clock.Now()); err != nil {
+		s.Logger.Error("heartbeat_failed", "Failed to Heartbeat", "", err)
 		return err
 	}
-	worker.Heartbeat()
-	return s.repo.Update(ctx, worker)
+	return nil
 }
 
 func (s *WorkerService) AddProcessedOrder(ctx context.Context, workerName string) error {
-	worker, err := s.GetWorkerByName(ctx, workerName)
-	if err != nil {
-		s.Logger.Error("worker_not_found", "Failed to Add Prosses Order", "", err)
+	if err := s.repo.IncrementProcessed(ctx, workerName, s.clock.Now()); err != nil {
+		s.Logger.Error("add_processed_order_failed", "Failed to Add Prosses Order", "", err)
 		return err
 	}
-	worker.ProcessOrder()
-	return s.repo.Update(ctx, worker)
+	return nil
+}
+
+// UpdateWorkerType сохраняет новый набор типов заказов, которые готовит воркер
+func (s *WorkerService) UpdateWorkerType(ctx context.Context, workerName, workerType string) error {
+	if err := s.repo.SetType(ctx, workerName, workerType); err != nil {
+		s.Logger.Error("update_worker_type_failed", "Failed to Update Worker Type", "", err)
+		return err
+	}
+	return nil
 }
 
 func (s *WorkerService) GetAllWorkers(ctx context.Context) ([]domain.Worker, error) {
@@ -137,14 +137,14 @@ func (s *WorkerService) GetWorkerByName(ctx context.Context, name string) (*doma
 }
 
 func (s *WorkerService) SetOnline(ctx context.Context, worker *domain.Worker) error {
-	if err := worker.GoOnline(); err != nil {
+	if err := worker.GoOnline(s.clock.Now()); err != nil {
 		return err
 	}
 	return s.repo.Update(ctx, worker)
 }
 
 func (s *WorkerService) SetOffline(ctx context.Context, worker *domain.Worker) error {
-	if err := worker.GoOffline(); err != nil {
+	if err := worker.GoOffline(s.clock.Now()); err != nil {
 		return err
 	}
 	return s.repo.Update(ctx, worker)
@@ -152,7 +152,7 @@ func (s *WorkerService) SetOffline(ctx context.Context, worker *domain.Worker) e
 
 func (s *WorkerService) updateLastSeenForOnlineWorkers(ctx context.Context) {
 	workers, _ := s.repo.GetAll(ctx)
-	now := time.Now()
+	now := s.clock.Now()
 	for _, worker := range workers {
 		if worker.Status == domain.WorkerOnline {
 			worker.LastSeen = now
@@ -10,6 +10,8 @@ import (
 	"restaurant-system/services/kitchen-service/app"
 	"restaurant-system/services/kitchen-service/config"
 	"restaurant-system/services/kitchen-service/utils/logger"
+	"restaurant-system/shared/clock"
+	"strings"
 	"syscall"
 	"time"
 )
@@ -19,6 +21,13 @@ type Config struct {
 	OrderType         string
 	Prefetch          int
 	HeartbeatInterval int
+	// Schedule — политика выбора заказа из буфера: fifo, priority, shortest, edf
+	Schedule string
+	// MaxCooking — сколько заказов готовить одновременно (по умолчанию 1); должно быть
+	// меньше Prefetch, иначе в планировщике не из чего выбирать
+	MaxCooking int
+	// TimeScale ускоряет готовку и heartbeat (60 = минута за секунду)
+	TimeScale float64
 }
 
 func Start(ctx context.Context, cfg Config) error {
@@ -31,6 +40,11 @@ func Start(ctx context.Context, cfg Config) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	scheduler, err := app.NewScheduler(cfg.Schedule)
+	if err != nil {
+		return err
+	}
+
 	// Загрузка конфигурации
 	appConfig, err := config.LoadConfig()
 	if err != nil {
@@ -62,22 +76,37 @@ func Start(ctx context.Context, cfg Config) error {
 	// Инициализация репозиториев и сервисов
 	workerRepo := postgre.NewPostgresWorkerRepo(dbPool, serviceName)
 	kitchenRepo := postgre.NewPostgresKitchenRepo(dbPool, serviceName)
+	metricsRepo := postgre.NewPostgresCookMetricsRepo(dbPool, serviceName)
+	inventoryRepo := postgre.NewPostgresInventoryRepo(dbPool, serviceName)
 
 	// Создание потребителя
-	consumer, err := rabbitmq.NewKitchenConsumer(rabbitClient, cfg.Prefetch, cfg.OrderType)
+	orderTypes := parseOrderTypes(cfg.OrderType)
+	consumer, err := rabbitmq.NewKitchenConsumer(rabbitClient, cfg.Prefetch, orderTypes)
 	if err != nil {
 		return fmt.Errorf("failed to create kitchen consumer: %w", err)
 	}
 
+	// Очередь команд управления воркером
+	controlConsumer, err := rabbitmq.NewControlConsumer(rabbitClient, cfg.WorkerName, serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to create control consumer: %w", err)
+	}
+
 	// Создание издателя
-	publisher := rabbitmq.NewNotificationPublisher(rabbitClient, serviceName)
+	clk := clock.New(cfg.TimeScale)
+	publisher := rabbitmq.NewNotificationPublisher(rabbitClient, clk, serviceName)
 
 	// Создание сервисов
-	workerSvc := app.NewWorkerService(workerRepo, serviceName)
-	kitchenSvc := app.NewKitchenService(workerSvc, consumer, publisher, kitchenRepo, cfg.WorkerName, serviceName)
+	workerSvc := app.NewWorkerService(workerRepo, clk, serviceName)
+	if cfg.MaxCooking >= cfg.Prefetch && cfg.Prefetch > 1 {
+		// буфер никогда не копится, и --schedule ни на что не влияет
+		log.Info("scheduler_idle", fmt.Sprintf("--max-cooking %d is not below --prefetch %d, orders start in arrival order", cfg.MaxCooking, cfg.Prefetch), "")
+	}
+	kitchenSvc := app.NewKitchenService(workerSvc, consumer, controlConsumer, publisher, kitchenRepo, metricsRepo, inventoryRepo, scheduler, clk, cfg.WorkerName, orderTypes, cfg.MaxCooking, serviceName)
 
 	// Регистрация воркера
-	if err := workerSvc.RegisterWorker(ctx, cfg.WorkerName, cfg.OrderType); err != nil {
+	heartbeatInterval := time.Duration(cfg.HeartbeatInterval) * time.Second
+	if err := workerSvc.RegisterWorker(ctx, cfg.WorkerName, cfg.OrderType, heartbeatInterval); err != nil {
 		return fmt.Errorf("failed to register worker: %w", err)
 	}
 
@@ -85,7 +114,7 @@ func Start(ctx context.Context, cfg Config) error {
 	heartbeatCtx, heartbeatCancel := context.WithCancel(ctx)
 	defer heartbeatCancel()
 
-	go workerSvc.StartHeartbeat(heartbeatCtx, cfg.WorkerName, time.Duration(cfg.HeartbeatInterval)*time.Second)
+	go workerSvc.StartHeartbeat(heartbeatCtx, cfg.WorkerName, heartbeatInterval)
 
 	// Канал для ошибок из kitchen service
 	serviceErr := make(chan error, 1)
@@ -95,7 +124,10 @@ func Start(ctx context.Context, cfg Config) error {
 		log.Info("service_started", fmt.Sprintf("Worker %s started processing %s orders", cfg.WorkerName, cfg.OrderType), "")
 		if err := kitchenSvc.Start(ctx); err != nil {
 			serviceErr <- fmt.Errorf("kitchen service failed: %w", err)
+			return
 		}
+		// nil означает штатную остановку (ctx или drain)
+		serviceErr <- nil
 	}()
 
 	// Обработка сигналов завершения
@@ -127,6 +159,7 @@ func Start(ctx context.Context, cfg Config) error {
 			cancel() // Отменяем контекст при ошибке
 			return err
 		}
+		log.Info("service_drained", "Kitchen service finished in-flight orders", "")
 	}
 
 	// Final cleanup - отмечаем воркера как offline
@@ -138,3 +171,13 @@ func Start(ctx context.Context, cfg Config) error {
 	log.Info("service_stopped", "Kitchen worker stopped gracefully", "")
 	return nil
 }
+
+func parseOrderTypes(raw string) []string {
+	var orderTypes []string
+	for _, orderType := range strings.Split(raw, ",") {
+		if orderType = strings.TrimSpace(orderType); orderType != "" {
+			orderTypes = append(orderTypes, orderType)
+		}
+	}
+	return orderTypes
+}
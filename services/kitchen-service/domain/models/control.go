@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrUnknownCommand = errors.New("unknown control command")
+
+type ControlCommandType string
+
+const (
+	CommandPause         ControlCommandType = "pause"
+	CommandResume        ControlCommandType = "resume"
+	CommandDrain         ControlCommandType = "drain"
+	CommandSetOrderTypes ControlCommandType = "set_order_types"
+)
+
+type WorkerState string
+
+const (
+	StateRunning  WorkerState = "running"
+	StatePaused   WorkerState = "paused"
+	StateDraining WorkerState = "draining"
+)
+
+// ControlCommand приходит из очереди управления воркером (routing key control.<worker>)
+type ControlCommand struct {
+	Command    ControlCommandType `json:"command"`
+	OrderTypes []string           `json:"order_types,omitempty"`
+	IssuedAt   time.Time          `json:"issued_at"`
+
+	// Свойства AMQP-сообщения, нужны для ответа
+	ReplyTo       string `json:"-"`
+	CorrelationID string `json:"-"`
+}
+
+// ControlAck отправляется обратно отправителю команды
+type ControlAck struct {
+	WorkerName string             `json:"worker_name"`
+	Command    ControlCommandType `json:"command"`
+	Accepted   bool               `json:"accepted"`
+	State      WorkerState        `json:"state"`
+	OrderTypes []string           `json:"order_types"`
+	InFlight   int                `json:"in_flight"`
+	Buffered   int                `json:"buffered"`
+	Error      string             `json:"error,omitempty"`
+	Timestamp  time.Time          `json:"timestamp"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+var ErrOrderCancelled = errors.New("order cancelled")
+
+// LowStockAlert — ингредиент опустился до порога после списания
+type LowStockAlert struct {
+	Ingredient        string
+	Unit              string
+	StockQuantity     float64
+	LowStockThreshold float64
+}
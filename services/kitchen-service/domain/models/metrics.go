@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// CookMetric — замер одного приготовленного заказа
+type CookMetric struct {
+	OrderNumber string
+	WorkerName  string
+	OrderType   string
+	// StartedAt и FinishedAt — настенное время, как и в order_status_log
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Estimated и Actual — симулированное время (с учётом --time-scale), чтобы
+	// их можно было сравнивать между собой и с kitchen.CookTimes;
+	// при --time-scale FinishedAt-StartedAt не равно Actual
+	Estimated time.Duration
+	Actual    time.Duration
+}
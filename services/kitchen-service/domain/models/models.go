@@ -25,35 +25,37 @@ type Worker struct {
 	OrdersProcessed int64
 	LastSeen        time.Time
 	CreatedAt       time.Time
+	// HeartbeatInterval — как часто воркер обновляет last_seen; tracking считает по нему живость
+	HeartbeatInterval time.Duration
 }
 
 // GoOnline переводит работника в статус online
-func (w *Worker) GoOnline() error {
+func (w *Worker) GoOnline(now time.Time) error {
 	if w.Status == WorkerOnline {
 		return ErrWorkerAlreadyOnline
 	}
 	w.Status = WorkerOnline
-	w.LastSeen = time.Now()
+	w.LastSeen = now
 	return nil
 }
 
 // GoOffline переводит работника в статус offline
-func (w *Worker) GoOffline() error {
+func (w *Worker) GoOffline(now time.Time) error {
 	if w.Status == WorkerOffline {
 		return ErrWorkerAlreadyOffline
 	}
 	w.Status = WorkerOffline
-	w.LastSeen = time.Now()
+	w.LastSeen = now
 	return nil
 }
 
 // Heartbeat обновляет время последней активности
-func (w *Worker) Heartbeat() {
-	w.LastSeen = time.Now()
+func (w *Worker) Heartbeat(now time.Time) {
+	w.LastSeen = now
 }
 
 // ProcessOrder увеличивает счётчик заказов
-func (w *Worker) ProcessOrder() {
+func (w *Worker) ProcessOrder(now time.Time) {
 	w.OrdersProcessed++
-	w.LastSeen = time.Now()
+	w.LastSeen = now
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"restaurant-system/shared/kitchen"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -26,6 +27,10 @@ type OrderMessage struct {
 	TotalAmount     float64
 	Priority        int
 	Delivery        amqp.Delivery
+	// ReceivedAt — когда воркер забрал заказ из очереди
+	ReceivedAt time.Time `json:"-"`
+	// CorrelationID из события создания заказа, переносится во все события заказа
+	CorrelationID string `json:"-"`
 }
 
 type OrderItemRequest struct {
@@ -34,13 +39,19 @@ type OrderItemRequest struct {
 	Price    float64 `json:"price"`
 }
 
+// OrderStatusUpdated уходит в notifications_fanout как events.OrderStatusChanged
 type OrderStatusUpdated struct {
-	OrderNumber         string    `json:"order_number"`
-	OldStatus           string    `json:"old_status"`
-	NewStatus           string    `json:"new_status"`
-	ChangedBy           string    `json:"changed_by"`
-	Timestamp           time.Time `json:"timestamp"`
-	EstimatedCompletion time.Time `json:"estimated_completion,omitempty"`
+	OrderNumber   string
+	OrderType     string
+	OldStatus     string
+	NewStatus     string
+	ChangedBy     string
+	Timestamp     time.Time
+	CorrelationID string
+	// EstimatedCompletion — nil, если оценки нет
+	EstimatedCompletion *time.Time
+	// StatusLogID — запись order_status_log перехода; 0 — событие получит случайный id
+	StatusLogID int64
 }
 
 type OrderStatusLog struct {
@@ -53,15 +64,17 @@ type OrderStatusLog struct {
 	CreatedAt time.Time
 }
 
+// CookingTime — время готовки по типу заказа (общая таблица shared/kitchen)
 func (o *OrderMessage) CookingTime() time.Duration {
-	switch o.OrderType {
-	case "dine_in":
-		return 8 * time.Second
-	case "takeout":
-		return 10 * time.Second
-	case "delivery":
-		return 12 * time.Second
-	default:
-		return 10 * time.Second
-	}
+	return kitchen.CookingTime(o.OrderType)
+}
+
+// MaxWait — сколько заказ может ждать начала готовки в зависимости от приоритета
+func (o *OrderMessage) MaxWait() time.Duration {
+	return kitchen.MaxWait(o.Priority)
+}
+
+// Deadline — к какому моменту заказ должен быть готов
+func (o *OrderMessage) Deadline() time.Time {
+	return o.ReceivedAt.Add(o.MaxWait() + o.CookingTime())
 }
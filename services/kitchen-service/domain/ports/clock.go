@@ -0,0 +1,12 @@
+package ports
+
+import "time"
+
+// Clock — источник времени; в режиме симуляции длительности ускорены
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	// Scale — во сколько раз симуляция быстрее реального времени
+	Scale() float64
+}
@@ -7,6 +7,7 @@ import (
 
 type MessageConsumer interface {
 	ConsumeOrders(ctx context.Context) (<-chan domain.OrderMessage, error)
+	Subscribe(orderTypes []string) error
 	AckMessage(message domain.OrderMessage) error
 	NackMessage(message domain.OrderMessage, requeue bool) error
 }
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+)
+
+type ControlConsumer interface {
+	// Команды управления конкретным воркером
+	ConsumeCommands(ctx context.Context) (<-chan domain.ControlCommand, error)
+	Acknowledge(ctx context.Context, command domain.ControlCommand, ack domain.ControlAck) error
+}
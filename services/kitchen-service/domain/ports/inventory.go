@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+)
+
+type InventoryRepository interface {
+	// Списывает зарезервированные ингредиенты заказа и возвращает те, что упали до порога
+	ConsumeReservations(ctx context.Context, orderNumber string) ([]domain.LowStockAlert, error)
+}
@@ -6,6 +6,6 @@ import (
 )
 
 type KitchenOrderRepository interface {
-	// Локальное управление заказами кухни
-	UpdateOrderStatus(ctx context.Context, orderNumber string, status domain.OrderStatus, processedBy string) error
+	// Локальное управление заказами кухни; возвращает id записи в order_status_log
+	UpdateOrderStatus(ctx context.Context, orderNumber string, status domain.OrderStatus, processedBy string) (int64, error)
 }
@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+	domain "restaurant-system/services/kitchen-service/domain/models"
+)
+
+type CookMetricsRepository interface {
+	SaveCookMetric(ctx context.Context, metric domain.CookMetric) error
+}
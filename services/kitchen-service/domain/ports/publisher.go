@@ -3,11 +3,14 @@ package ports
 import (
 	"context"
 	domain "restaurant-system/services/kitchen-service/domain/models"
+	"time"
 )
 
 type StatusPublisher interface {
 	// Публикация событий изменения статусов
 	PublishStatusUpdate(ctx context.Context, event domain.OrderStatusUpdated) error
-	PublishCookingStarted(ctx context.Context, order domain.OrderMessage, workerName string) error
-	PublishOrderReady(ctx context.Context, order domain.OrderMessage, workerName string) error
+	// statusLogID — запись order_status_log этого перехода, из неё строится id события
+	PublishCookingStarted(ctx context.Context, order domain.OrderMessage, workerName string, statusLogID int64, estimatedCompletion time.Time) error
+	PublishOrderReady(ctx context.Context, order domain.OrderMessage, workerName string, statusLogID int64) error
+	PublishLowStock(ctx context.Context, alert domain.LowStockAlert, orderNumber string) error
 }
@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	domain "restaurant-system/services/kitchen-service/domain/models"
+	"time"
 )
 
 type WorkerRepository interface {
@@ -10,4 +11,10 @@ type WorkerRepository interface {
 	Update(ctx context.Context, worker *domain.Worker) error
 	GetAll(ctx context.Context) ([]domain.Worker, error)
 	GetByName(ctx context.Context, name string) (*domain.Worker, error)
+	// Точечные обновления: heartbeat и готовка пишут одну строку параллельно,
+	// поэтому каждое меняет только свои колонки
+	Touch(ctx context.Context, name string, lastSeen time.Time) error
+	IncrementProcessed(ctx context.Context, name string, lastSeen time.Time) error
+	SetStatus(ctx context.Context, name string, status domain.WorkerStatus, lastSeen time.Time) error
+	SetType(ctx context.Context, name, workerType string) error
 }
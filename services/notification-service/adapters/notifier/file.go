@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"restaurant-system/services/notification-service/domain/models"
+	"sync"
+)
+
+const ChannelFile = "file"
+
+// FileNotifier appends one JSON line per notification, to a file or to stdout
+type FileNotifier struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewFileNotifier(path string) (*FileNotifier, error) {
+	if path == "" || path == "-" || path == "stdout" {
+		return &FileNotifier{out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open notification file: %w", err)
+	}
+	return &FileNotifier{out: file}, nil
+}
+
+func (n *FileNotifier) Channel() string         { return ChannelFile }
+func (n *FileNotifier) CustomerChannel() string { return "" }
+
+func (n *FileNotifier) Send(ctx context.Context, notification models.Notification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err = n.out.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+func TestFileNotifierAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	if err := os.WriteFile(path, []byte("earlier\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := NewFileNotifier(path)
+	if err != nil {
+		t.Fatalf("NewFileNotifier: %v", err)
+	}
+	for _, status := range []string{"cooking", "ready"} {
+		if err := n.Send(context.Background(), models.Notification{OrderNumber: "ORD_1", NewStatus: status}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "earlier\n" +
+		`{"order_number":"ORD_1","old_status":"","new_status":"cooking","changed_by":"","subject":"","message":"","timestamp":""}` + "\n" +
+		`{"order_number":"ORD_1","old_status":"","new_status":"ready","changed_by":"","subject":"","message":"","timestamp":""}` + "\n"
+	if string(got) != want {
+		t.Errorf("file =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNewFileNotifierBadPath(t *testing.T) {
+	if _, err := NewFileNotifier(filepath.Join(t.TempDir(), "missing", "notifications.log")); err == nil {
+		t.Error("a path in a missing directory should fail")
+	}
+}
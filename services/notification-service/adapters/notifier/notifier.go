@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"fmt"
+	"restaurant-system/services/notification-service/config"
+	"restaurant-system/services/notification-service/domain/ports"
+)
+
+// FromConfig builds the notifiers listed in NOTIFY_CHANNELS, checking each one's settings
+func FromConfig(cfg config.NotifierConfig) ([]ports.Notifier, error) {
+	var notifiers []ports.Notifier
+	for _, channel := range cfg.Channels {
+		switch channel {
+		case ChannelFile:
+			notifier, err := NewFileNotifier(cfg.FilePath)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, notifier)
+		case ChannelSMTP:
+			if cfg.SMTPHost == "" || cfg.SMTPFrom == "" {
+				return nil, fmt.Errorf("smtp channel needs SMTP_HOST and SMTP_FROM")
+			}
+			notifiers = append(notifiers, NewSMTPNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPFrom))
+		case ChannelWebhook:
+			if cfg.WebhookURL == "" {
+				return nil, fmt.Errorf("webhook channel needs NOTIFY_WEBHOOK_URL")
+			}
+			notifiers = append(notifiers, NewWebhookNotifier(cfg.WebhookURL))
+		case ChannelSMS:
+			if cfg.SMSGatewayURL == "" {
+				return nil, fmt.Errorf("sms channel needs SMS_GATEWAY_URL")
+			}
+			notifiers = append(notifiers, NewSMSNotifier(cfg.SMSGatewayURL, cfg.SMSGatewayToken, cfg.SMSSender))
+		default:
+			return nil, fmt.Errorf("unknown notification channel %q", channel)
+		}
+	}
+	return notifiers, nil
+}
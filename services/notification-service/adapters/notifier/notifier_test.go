@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"restaurant-system/services/notification-service/config"
+)
+
+func TestFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	full := config.NotifierConfig{
+		FilePath:      filepath.Join(dir, "notifications.log"),
+		SMTPHost:      "mail.example.com",
+		SMTPPort:      587,
+		SMTPFrom:      "kitchen@example.com",
+		WebhookURL:    "http://hooks.example.com/orders",
+		SMSGatewayURL: "http://sms.example.com/send",
+	}
+	with := func(channels []string, change func(*config.NotifierConfig)) config.NotifierConfig {
+		cfg := full
+		cfg.Channels = channels
+		if change != nil {
+			change(&cfg)
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		cfg     config.NotifierConfig
+		want    []string
+		wantErr string
+	}{
+		{"all channels", with([]string{"file", "smtp", "webhook", "sms"}, nil), []string{"file", "smtp", "webhook", "sms"}, ""},
+		{"none", with(nil, nil), nil, ""},
+		{"smtp without host", with([]string{"smtp"}, func(c *config.NotifierConfig) { c.SMTPHost = "" }), nil, "SMTP_HOST"},
+		{"smtp without sender", with([]string{"smtp"}, func(c *config.NotifierConfig) { c.SMTPFrom = "" }), nil, "SMTP_FROM"},
+		{"webhook without url", with([]string{"webhook"}, func(c *config.NotifierConfig) { c.WebhookURL = "" }), nil, "NOTIFY_WEBHOOK_URL"},
+		{"sms without gateway", with([]string{"sms"}, func(c *config.NotifierConfig) { c.SMSGatewayURL = "" }), nil, "SMS_GATEWAY_URL"},
+		{"unwritable file", with([]string{"file"}, func(c *config.NotifierConfig) { c.FilePath = filepath.Join(dir, "missing", "x.log") }), nil, "notification file"},
+		{"unknown channel", with([]string{"file", "pigeon"}, nil), nil, `"pigeon"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifiers, err := FromConfig(tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to mention %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromConfig: %v", err)
+			}
+			var got []string
+			for _, n := range notifiers {
+				got = append(got, n.Channel())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("channels = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
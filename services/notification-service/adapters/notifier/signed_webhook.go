@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"restaurant-system/services/notification-service/domain/models"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every subscriber webhook
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+// SignedWebhookSender POSTs events to webhook subscribers, signed with each subscription's secret
+type SignedWebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSignedWebhookSender() *SignedWebhookSender {
+	return &SignedWebhookSender{
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}
+}
+
+func (s *SignedWebhookSender) Send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, delivery.EventID)
+	req.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderWebhookSignature, "sha256="+Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request to %s failed: %w", delivery.URL, err)
+	}
+	defer resp.Body.Close()
+	// drain a little so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%s answered %s", delivery.URL, resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign is the hex HMAC-SHA256 of "<timestamp>.<body>"; subscribers recompute it to
+// verify the sender and reject old timestamps to stop replays
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
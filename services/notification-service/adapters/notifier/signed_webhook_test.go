@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+func TestSign(t *testing.T) {
+	// computed independently: HMAC-SHA256("topsecret", "1792411200." + body)
+	want := "9cea8506f7a42e0e259c34272f29f99a72825f531f298c00cc774512a56b832b"
+	if got := Sign("topsecret", "1792411200", []byte(`{"order_number":"ORD_1"}`)); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestSignedWebhookSender(t *testing.T) {
+	payload := []byte(`{"order_number":"ORD_1"}`)
+	var got *http.Request
+	var body []byte
+	status := http.StatusNoContent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender := NewSignedWebhookSender()
+	sender.now = func() time.Time { return time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC) }
+
+	delivery := models.WebhookDelivery{
+		URL:       server.URL,
+		Secret:    "topsecret",
+		EventID:   "4b1e7c2a90d14f3e8b5a6c7d8e9f0a1b",
+		EventType: "order.status_changed",
+		Payload:   payload,
+	}
+
+	code, err := sender.Send(context.Background(), delivery)
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("Send = %d, %v", code, err)
+	}
+	if string(body) != string(payload) {
+		t.Errorf("body %s, want %s", body, payload)
+	}
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		HeaderWebhookID:        delivery.EventID,
+		HeaderWebhookEvent:     delivery.EventType,
+		HeaderWebhookTimestamp: "1792411200",
+		HeaderWebhookSignature: "sha256=9cea8506f7a42e0e259c34272f29f99a72825f531f298c00cc774512a56b832b",
+	}
+	for name, want := range headers {
+		if value := got.Header.Get(name); value != want {
+			t.Errorf("%s = %q, want %q", name, value, want)
+		}
+	}
+
+	// anything but 2xx is a failed send that still reports the status
+	status = http.StatusGone
+	code, err = sender.Send(context.Background(), delivery)
+	if err == nil || code != http.StatusGone {
+		t.Errorf("Send = %d, %v; want 410 and an error", code, err)
+	}
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"restaurant-system/services/notification-service/domain/models"
+	"time"
+)
+
+const ChannelSMS = "sms"
+
+// SMSNotifier hands the text to an HTTP SMS gateway:
+// POST {"to": ..., "from": ..., "text": ...} with a bearer token
+type SMSNotifier struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+type smsRequest struct {
+	To   string `json:"to"`
+	From string `json:"from,omitempty"`
+	Text string `json:"text"`
+}
+
+func NewSMSNotifier(url, token, sender string) *SMSNotifier {
+	return &SMSNotifier{
+		url:    url,
+		token:  token,
+		sender: sender,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *SMSNotifier) Channel() string         { return ChannelSMS }
+func (n *SMSNotifier) CustomerChannel() string { return "sms" }
+
+func (n *SMSNotifier) Send(ctx context.Context, notification models.Notification) error {
+	if notification.Phone == "" {
+		return models.ErrNoRecipient
+	}
+
+	body, err := json.Marshal(smsRequest{
+		To:   notification.Phone,
+		From: n.sender,
+		Text: notification.Message,
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.client, n.url, n.token, body)
+}
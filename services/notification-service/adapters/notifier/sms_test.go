@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+func TestSMSNotifierSend(t *testing.T) {
+	tests := []struct {
+		name    string
+		sender  string
+		status  int
+		wantErr bool
+		want    string
+	}{
+		{"with sender", "Kitchen", http.StatusOK, false, `{"to":"+77011234567","from":"Kitchen","text":"Order ORD_1 is ready"}`},
+		{"gateway default sender", "", http.StatusCreated, false, `{"to":"+77011234567","text":"Order ORD_1 is ready"}`},
+		{"gateway refuses", "Kitchen", http.StatusTooManyRequests, true, `{"to":"+77011234567","from":"Kitchen","text":"Order ORD_1 is ready"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			n := NewSMSNotifier(server.URL, "gw-token", tt.sender)
+			err := n.Send(context.Background(), models.Notification{OrderNumber: "ORD_1", Message: "Order ORD_1 is ready", Phone: "+77011234567"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if auth := got.Header.Get("Authorization"); auth != "Bearer gw-token" {
+				t.Errorf("Authorization = %q", auth)
+			}
+			if string(body) != tt.want {
+				t.Errorf("body %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestSMSNotifierNoRecipient(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+	defer server.Close()
+
+	err := NewSMSNotifier(server.URL, "", "").Send(context.Background(), models.Notification{OrderNumber: "ORD_1", Email: "alice@example.com"})
+	if !errors.Is(err, models.ErrNoRecipient) {
+		t.Errorf("err = %v, want ErrNoRecipient", err)
+	}
+	if called {
+		t.Error("gateway called without a phone number")
+	}
+}
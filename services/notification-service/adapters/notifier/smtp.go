@@ -0,0 +1,136 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"restaurant-system/services/notification-service/domain/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ChannelSMTP = "smtp"
+
+// smtpTimeout bounds a session when the caller's context has no deadline
+const smtpTimeout = 30 * time.Second
+
+// SMTPNotifier emails the customer through a relay
+type SMTPNotifier struct {
+	host string
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPNotifier(host string, port int, user, password, from string) *SMTPNotifier {
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, password, host)
+	}
+	return &SMTPNotifier{
+		host: host,
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (n *SMTPNotifier) Channel() string         { return ChannelSMTP }
+func (n *SMTPNotifier) CustomerChannel() string { return "email" }
+
+func (n *SMTPNotifier) Send(ctx context.Context, notification models.Notification) error {
+	if notification.Email == "" {
+		return models.ErrNoRecipient
+	}
+
+	if err := n.send(ctx, notification.Email, n.buildMessage(notification)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// send runs one SMTP session. net/smtp knows nothing about contexts, so the
+// context's deadline becomes the connection deadline and cancelling the
+// context expires it at once; a stuck relay can't hold the delivery forever.
+func (n *SMTPNotifier) send(ctx context.Context, to string, message []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	// same negotiation as smtp.SendMail: upgrade to TLS when offered, then authenticate
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (n *SMTPNotifier) buildMessage(notification models.Notification) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + n.from + "\r\n")
+	b.WriteString("To: " + notification.Email + "\r\n")
+	// localized subjects are not ASCII, so encode them per RFC 2047
+	b.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", sanitizeHeader(notification.Subject)) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(notification.Message, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// sanitizeHeader keeps user-influenced text from injecting extra headers
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
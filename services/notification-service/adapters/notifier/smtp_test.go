@@ -0,0 +1,164 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+// smtpSink is a one-session SMTP server that records the commands and the message it got
+type smtpSink struct {
+	listener net.Listener
+	done     chan struct{}
+	commands []string
+	data     string
+}
+
+// newSMTPSink answers like a relay; with silent set it accepts the connection and never greets
+func newSMTPSink(t *testing.T, silent bool) *smtpSink {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	sink := &smtpSink{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		defer close(sink.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if silent {
+			// wait for the client to give up
+			conn.Read(make([]byte, 1))
+			return
+		}
+		sink.serve(conn)
+	}()
+	return sink
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 sink ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.commands = append(s.commands, line)
+
+		switch verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); verb {
+		case "EHLO", "HELO", "MAIL", "RCPT":
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.data = data.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestSMTPNotifierSend(t *testing.T) {
+	sink := newSMTPSink(t, false)
+	n := NewSMTPNotifier("127.0.0.1", sink.port(), "", "", "kitchen@example.com")
+
+	err := n.Send(context.Background(), models.Notification{
+		OrderNumber: "ORD_1",
+		Subject:     "Заказ ORD_1 готов",
+		Message:     "Your order is ready.\nEnjoy!",
+		Email:       "alice@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-sink.done
+
+	want := []string{"MAIL FROM:<kitchen@example.com>", "RCPT TO:<alice@example.com>", "DATA", "QUIT"}
+	if got := sink.commands[1:]; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("commands = %q, want EHLO then %q", sink.commands, want)
+	}
+	for _, line := range []string{
+		"From: kitchen@example.com\r\n",
+		"To: alice@example.com\r\n",
+		"Subject: =?UTF-8?q?",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\n\r\nYour order is ready.\r\nEnjoy!\r\n",
+	} {
+		if !strings.Contains(sink.data, line) {
+			t.Errorf("message lacks %q:\n%s", line, sink.data)
+		}
+	}
+}
+
+func TestSMTPNotifierNoRecipient(t *testing.T) {
+	n := NewSMTPNotifier("127.0.0.1", 1, "", "", "kitchen@example.com")
+	if err := n.Send(context.Background(), models.Notification{OrderNumber: "ORD_1"}); !errors.Is(err, models.ErrNoRecipient) {
+		t.Errorf("err = %v, want ErrNoRecipient", err)
+	}
+}
+
+func TestSMTPNotifierHonoursDeadline(t *testing.T) {
+	sink := newSMTPSink(t, true)
+	n := NewSMTPNotifier("127.0.0.1", sink.port(), "", "", "kitchen@example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := n.Send(ctx, models.Notification{OrderNumber: "ORD_1", Email: "alice@example.com"})
+	if err == nil {
+		t.Fatal("Send to a relay that never answers should fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send took %v, want it bounded by the context deadline", elapsed)
+	}
+}
+
+func TestSMTPNotifierConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	n := NewSMTPNotifier("127.0.0.1", port, "", "", "kitchen@example.com")
+	err = n.Send(context.Background(), models.Notification{OrderNumber: "ORD_1", Email: "alice@example.com"})
+	if err == nil || !strings.Contains(err.Error(), strconv.Itoa(port)) {
+		t.Errorf("err = %v, want a connection error", err)
+	}
+}
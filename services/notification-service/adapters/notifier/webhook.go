@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"restaurant-system/services/notification-service/domain/models"
+	"time"
+)
+
+const ChannelWebhook = "webhook"
+
+// WebhookNotifier POSTs the notification as JSON to a fixed URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) Channel() string         { return ChannelWebhook }
+func (n *WebhookNotifier) CustomerChannel() string { return "" }
+
+func (n *WebhookNotifier) Send(ctx context.Context, notification models.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.client, n.url, "", body)
+}
+
+// postJSON sends body and treats any non-2xx answer as a failure
+func postJSON(ctx context.Context, client *http.Client, url, bearerToken string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+func TestWebhookNotifierSend(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"accepted", http.StatusAccepted, false},
+		{"rejected", http.StatusBadRequest, true},
+		{"server error", http.StatusBadGateway, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			notification := models.Notification{OrderNumber: "ORD_1", OldStatus: "cooking", NewStatus: "ready", Message: "ready"}
+			err := NewWebhookNotifier(server.URL).Send(context.Background(), notification)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got.Method != http.MethodPost || got.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("request %s with Content-Type %q", got.Method, got.Header.Get("Content-Type"))
+			}
+			if got.Header.Get("Authorization") != "" {
+				t.Error("webhook should not send a bearer token")
+			}
+			var sent models.Notification
+			if err := json.Unmarshal(body, &sent); err != nil || sent != notification {
+				t.Errorf("body %s, want %+v (%v)", body, notification, err)
+			}
+		})
+	}
+}
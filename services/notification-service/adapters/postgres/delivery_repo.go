@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresDeliveryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresDeliveryRepository(db *pgxpool.Pool) ports.DeliveryRepository {
+	return &PostgresDeliveryRepository{db: db}
+}
+
+// rowQuerier is a pool or a transaction
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insertDelivery(ctx context.Context, db rowQuerier, delivery *models.Delivery) error {
+	payload, err := json.Marshal(delivery.Notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	query := `
+		INSERT INTO notification_deliveries (
+			order_number, order_status, channel, recipient, payload,
+			status, attempts, last_error, next_attempt_at, delivered_at
+		)
+		VALUES ($1, $2, $3, nullif($4, ''), $5, $6, $7, nullif($8, ''), $9, $10)
+		RETURNING id
+	`
+
+	err = db.QueryRow(ctx, query,
+		delivery.OrderNumber,
+		delivery.OrderStatus,
+		delivery.Channel,
+		delivery.Recipient,
+		payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save notification delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Delivery, error) {
+	query := `
+		UPDATE notification_deliveries d
+		SET next_attempt_at = now() + make_interval(secs => $2),
+			updated_at = now()
+		WHERE d.id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING
+			d.id,
+			d.order_number,
+			d.order_status,
+			d.channel,
+			coalesce(d.recipient, ''),
+			d.payload,
+			d.status,
+			d.attempts,
+			coalesce(d.last_error, '')
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notification deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.Delivery
+	for rows.Next() {
+		var delivery models.Delivery
+		var payload []byte
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.OrderNumber,
+			&delivery.OrderStatus,
+			&delivery.Channel,
+			&delivery.Recipient,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+		if err := json.Unmarshal(payload, &delivery.Notification); err != nil {
+			return nil, fmt.Errorf("failed to decode notification %d: %w", delivery.ID, err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read notification deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *PostgresDeliveryRepository) Update(ctx context.Context, delivery *models.Delivery) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = $2,
+			attempts = $3,
+			last_error = nullif($4, ''),
+			next_attempt_at = $5,
+			delivered_at = $6,
+			updated_at = now()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery %d: %w", delivery.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresEventStateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresEventStateRepository(db *pgxpool.Pool) ports.EventStateRepository {
+	return &PostgresEventStateRepository{db: db}
+}
+
+func (r *PostgresEventStateRepository) Accept(ctx context.Context, eventID, orderNumber, status string, predecessors []string, deliveries []*models.Delivery) (models.EventDecision, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.EventAccepted, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if eventID != "" {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO notification_processed_events (event_id)
+			VALUES ($1)
+			ON CONFLICT (event_id) DO NOTHING
+		`, eventID)
+		if err != nil {
+			return models.EventAccepted, fmt.Errorf("failed to record event %s: %w", eventID, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return models.EventDuplicate, nil
+		}
+	}
+
+	decision := models.EventAccepted
+	if predecessors != nil {
+		// the update only happens when the order's last status may precede the new one
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO notification_order_state (order_number, last_status)
+			VALUES ($1, $2)
+			ON CONFLICT (order_number) DO UPDATE
+			SET last_status = excluded.last_status,
+				updated_at = now()
+			WHERE notification_order_state.last_status = ANY($3)
+		`, orderNumber, status, predecessors)
+		if err != nil {
+			return models.EventAccepted, fmt.Errorf("failed to update notification state for order %s: %w", orderNumber, err)
+		}
+		if tag.RowsAffected() == 0 {
+			decision = models.EventOutOfOrder
+		}
+	}
+
+	if decision == models.EventAccepted {
+		for _, delivery := range deliveries {
+			if err := insertDelivery(ctx, tx, delivery); err != nil {
+				return models.EventAccepted, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.EventAccepted, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return decision, nil
+}
+
+func (r *PostgresEventStateRepository) PruneEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM notification_processed_events WHERE processed_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune processed events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRecipientRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresRecipientRepository(db *pgxpool.Pool) ports.RecipientRepository {
+	return &PostgresRecipientRepository{db: db}
+}
+
+func (r *PostgresRecipientRepository) GetRecipient(ctx context.Context, orderNumber string) (*models.Recipient, error) {
+	query := `
+		SELECT
+			o.customer_name,
+			coalesce(o.customer_email, ''),
+			coalesce(o.customer_phone, ''),
+			p.channels,
+			p.statuses,
+			p.locale
+		FROM orders o
+		JOIN notification_preferences p ON p.order_id = o.id
+		WHERE o.number = $1
+	`
+
+	var recipient models.Recipient
+	err := r.db.QueryRow(ctx, query, orderNumber).Scan(
+		&recipient.CustomerName,
+		&recipient.Email,
+		&recipient.Phone,
+		&recipient.Channels,
+		&recipient.Statuses,
+		&recipient.Locale,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification recipient: %w", err)
+	}
+
+	return &recipient, nil
+}
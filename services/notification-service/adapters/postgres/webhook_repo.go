@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresWebhookRepository(db *pgxpool.Pool) ports.WebhookRepository {
+	return &PostgresWebhookRepository{db: db}
+}
+
+func (r *PostgresWebhookRepository) Enqueue(ctx context.Context, event models.WebhookEvent) (int64, error) {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at)
+		SELECT id, $1, $2, $3, 'pending', now()
+		FROM webhook_subscriptions
+		WHERE active AND $2 = any(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, event.EventID, event.EventType, event.Payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *PostgresWebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries d
+			SET next_attempt_at = now() + make_interval(secs => $2),
+				updated_at = now()
+			WHERE d.id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= now()
+				ORDER BY next_attempt_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload,
+			          d.status, d.attempts, coalesce(d.last_error, '') AS last_error
+		)
+		SELECT c.id, c.subscription_id, s.url, s.secret, c.event_id, c.event_type,
+		       c.payload, c.status, c.attempts, c.last_error
+		FROM claimed c
+		JOIN webhook_subscriptions s ON s.id = c.subscription_id
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.URL,
+			&delivery.Secret,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *PostgresWebhookRepository) Update(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2,
+			attempts = $3,
+			response_status = $4,
+			last_error = nullif($5, ''),
+			next_attempt_at = $6,
+			delivered_at = $7,
+			updated_at = now()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery %d: %w", delivery.ID, err)
+	}
+	return nil
+}
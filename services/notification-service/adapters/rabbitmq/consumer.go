@@ -1,19 +1,48 @@
 package rabbitmq
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"regexp"
 	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/shared/events"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+const consumerTag = "notification-consumer"
+
+// Named subscriptions become part of a queue name, keep them simple
+var subscriptionRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
 type NotificationConsumer struct {
 	client *Client
+	// subscription names a durable queue; empty means a throwaway queue per run
+	subscription string
+	queue        string
+	// done is closed once the delivery loop has handled its last message
+	done chan struct{}
+	// stopping is closed by Stop to cut short the wait before a requeue
+	stopping chan struct{}
 }
 
-func NewNotificationConsumer(client *Client) *NotificationConsumer {
-	return &NotificationConsumer{client: client}
+func NewNotificationConsumer(client *Client, subscription string) (*NotificationConsumer, error) {
+	if subscription != "" && !subscriptionRegex.MatchString(subscription) {
+		return nil, fmt.Errorf("invalid subscription %q: use lowercase letters, digits, '-' and '_'", subscription)
+	}
+	return &NotificationConsumer{client: client, subscription: subscription}, nil
 }
 
+// Setup declares the subscriber's single queue and binds it to notifications_fanout.
+// A named subscription gets a durable queue "notifications.<name>" that keeps
+// collecting events while the subscriber is down; without one the queue is
+// server-named, exclusive and auto-deleted when the connection closes.
 func (c *NotificationConsumer) Setup() error {
 	// Declare fanout exchange
 	err := c.client.DeclareExchange("notifications_fanout", "fanout")
@@ -21,8 +50,12 @@ func (c *NotificationConsumer) Setup() error {
 		return err
 	}
 
-	// Declare queue (let RabbitMQ generate a unique name for each subscriber)
-	queue, err := c.client.DeclareQueue("") // empty name = auto-generate
+	var queue amqp.Queue
+	if c.subscription != "" {
+		queue, err = c.client.DeclareQueue("notifications." + c.subscription)
+	} else {
+		queue, err = c.client.DeclareExclusiveQueue("") // empty name = auto-generate
+	}
 	if err != nil {
 		return err
 	}
@@ -33,50 +66,187 @@ func (c *NotificationConsumer) Setup() error {
 		return err
 	}
 
+	c.queue = queue.Name
 	log.Printf("Notification queue '%s' bound to notifications_fanout exchange", queue.Name)
 
 	return nil
 }
 
-func (c *NotificationConsumer) StartConsuming(handler func(models.StatusUpdateMessage)) error {
-	// Get queue name by declaring again (since we used auto-generate)
-	queue, err := c.client.DeclareQueue("")
-	if err != nil {
-		return err
-	}
+// Requeued messages wait before going back to the queue, longer after every failure
+// in a row, so an outage of PostgreSQL does not become a tight redelivery loop
+const (
+	minRequeueDelay = time.Second
+	maxRequeueDelay = 30 * time.Second
+)
 
-	// Bind to exchange
-	err = c.client.BindQueue(queue.Name, "notifications_fanout", "")
-	if err != nil {
-		return err
+// StartConsuming queues each status and low stock event for webhook subscribers
+// through eventHandler and then passes it to its own handler. Both fail only
+// before anything was sent: eventHandler only writes pending deliveries and adds
+// none for a redelivered event, and the status handler fails only when it could
+// not record the update. A failed message is requeued after requeueDelay; one whose
+// notifications went out is always acknowledged.
+func (c *NotificationConsumer) StartConsuming(handler func(models.StatusUpdateMessage) error, lowStockHandler func(models.LowStockMessage), eventHandler func(models.WebhookEvent) error) error {
+	if c.queue == "" {
+		return errors.New("notification queue is not set up")
 	}
 
 	// Start consuming
-	msgs, err := c.client.Consume(queue.Name, "notification-consumer")
+	msgs, err := c.client.Consume(c.queue, consumerTag)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Started consuming from queue: %s", queue.Name)
+	log.Printf("Started consuming from queue: %s", c.queue)
 
+	c.done = make(chan struct{})
+	c.stopping = make(chan struct{})
 	go func() {
+		defer close(c.done)
+		failures := 0
 		for msg := range msgs {
-			var statusUpdate models.StatusUpdateMessage
-
-			// Parse JSON message
-			if err := json.Unmarshal(msg.Body, &statusUpdate); err != nil {
-				log.Printf("Error parsing message: %v", err)
-				msg.Nack(false, false) // reject and don't requeue
+			err := c.handle(msg, handler, lowStockHandler, eventHandler)
+			if err == nil {
+				failures = 0
 				continue
 			}
 
-			// Call handler
-			handler(statusUpdate)
-
-			// Acknowledge message
-			msg.Ack(false)
+			failures++
+			delay := requeueDelay(failures)
+			log.Printf("Requeueing message in %s: %v", delay, err)
+			select {
+			case <-time.After(delay):
+			case <-c.stopping:
+			}
+			msg.Nack(false, true)
 		}
 	}()
 
 	return nil
 }
+
+// handle acknowledges or rejects the message itself and returns an error
+// only when it should be requeued
+func (c *NotificationConsumer) handle(msg amqp.Delivery, handler func(models.StatusUpdateMessage) error, lowStockHandler func(models.LowStockMessage), eventHandler func(models.WebhookEvent) error) error {
+	envelope, err := events.Peek(msg.Body)
+	if err != nil {
+		log.Printf("Error parsing message: %v", err)
+		msg.Nack(false, false) // reject and don't requeue
+		return nil
+	}
+
+	var notify func() error
+	switch envelope.EventType {
+	case events.TypeLowStock:
+		var lowStock events.LowStock
+		if err := json.Unmarshal(msg.Body, &lowStock); err != nil {
+			log.Printf("Error parsing low stock message: %v", err)
+			msg.Nack(false, false)
+			return nil
+		}
+		notify = func() error {
+			lowStockHandler(models.LowStockMessage{
+				Ingredient:        lowStock.Ingredient,
+				Unit:              lowStock.Unit,
+				StockQuantity:     lowStock.StockQuantity,
+				LowStockThreshold: lowStock.LowStockThreshold,
+				OrderNumber:       lowStock.OrderNumber,
+				Timestamp:         lowStock.Timestamp.Format(time.RFC3339),
+			})
+			return nil
+		}
+
+	// status events from publishers that predate the envelope have no type
+	case events.TypeOrderStatusChanged, "":
+		var statusChanged events.OrderStatusChanged
+		if err := json.Unmarshal(msg.Body, &statusChanged); err != nil {
+			log.Printf("Error parsing message: %v", err)
+			msg.Nack(false, false) // reject and don't requeue
+			return nil
+		}
+		update := models.StatusUpdateMessage{
+			EventID:             statusChanged.EventID,
+			CorrelationID:       statusChanged.CorrelationID,
+			OrderNumber:         statusChanged.OrderNumber,
+			OldStatus:           statusChanged.OldStatus,
+			NewStatus:           statusChanged.NewStatus,
+			ChangedBy:           statusChanged.ChangedBy,
+			Timestamp:           statusChanged.Timestamp.Format(time.RFC3339),
+			EstimatedCompletion: statusChanged.EstimatedCompletion,
+		}
+		// older kitchen builds send a zero time when there is no estimate
+		if update.EstimatedCompletion != nil && update.EstimatedCompletion.IsZero() {
+			update.EstimatedCompletion = nil
+		}
+		notify = func() error { return handler(update) }
+
+	default:
+		log.Printf("Ignoring %s event %s", envelope.EventType, envelope.EventID)
+		msg.Ack(false)
+		return nil
+	}
+
+	// webhooks first: queueing them sends nothing, so a failure here leaves the
+	// event safe to requeue, and a redelivery does not queue them twice
+	if err := eventHandler(webhookEvent(envelope, msg.Body)); err != nil {
+		return err
+	}
+	if err := notify(); err != nil {
+		return err
+	}
+
+	// Acknowledge message
+	msg.Ack(false)
+	return nil
+}
+
+// requeueDelay doubles the wait with every failure in a row, up to maxRequeueDelay
+func requeueDelay(failures int) time.Duration {
+	delay := minRequeueDelay
+	for i := 1; i < failures && delay < maxRequeueDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRequeueDelay)
+}
+
+// webhookEvent fills in what publishers that predate the envelope leave out,
+// so subscribers always get a type and an id they can deduplicate on. The id of
+// such an event is a hash of its body, so a redelivery gets the same id.
+func webhookEvent(envelope events.Envelope, body []byte) models.WebhookEvent {
+	event := models.WebhookEvent{
+		EventID:   envelope.EventID,
+		EventType: envelope.EventType,
+		Payload:   body,
+	}
+	if event.EventType == "" {
+		event.EventType = events.TypeOrderStatusChanged
+	}
+	if event.EventID == "" {
+		sum := sha256.Sum256(body)
+		event.EventID = hex.EncodeToString(sum[:16])
+	}
+	return event
+}
+
+// Done is closed when the delivery loop ends, after Stop or when the channel is lost
+func (c *NotificationConsumer) Done() <-chan struct{} {
+	return c.done
+}
+
+// Stop stops taking new messages and waits until the message being handled is
+// acknowledged or ctx expires; unacknowledged messages go back to the queue
+func (c *NotificationConsumer) Stop(ctx context.Context) error {
+	if c.done == nil {
+		return nil
+	}
+	close(c.stopping)
+	if err := c.client.CancelConsumer(consumerTag); err != nil {
+		return fmt.Errorf("failed to cancel consumer: %w", err)
+	}
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,152 @@
+package rabbitmq
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/shared/events"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestWebhookEvent(t *testing.T) {
+	body := []byte(`{"order_number":"ORD_1","new_status":"cooking"}`)
+
+	event := webhookEvent(events.Envelope{EventID: "e1", EventType: events.TypeLowStock}, body)
+	if event.EventID != "e1" || event.EventType != events.TypeLowStock || string(event.Payload) != string(body) {
+		t.Errorf("enveloped event changed: %+v", event)
+	}
+
+	legacy := webhookEvent(events.Envelope{}, body)
+	if legacy.EventType != events.TypeOrderStatusChanged {
+		t.Errorf("legacy event type %q, want %q", legacy.EventType, events.TypeOrderStatusChanged)
+	}
+	if len(legacy.EventID) != 32 {
+		t.Errorf("legacy event id %q is not 128 bits of hex", legacy.EventID)
+	}
+	// a redelivered legacy event must keep its id so subscriptions get it once
+	if again := webhookEvent(events.Envelope{}, body); again.EventID != legacy.EventID {
+		t.Errorf("redelivery got id %q, want %q", again.EventID, legacy.EventID)
+	}
+	other := webhookEvent(events.Envelope{}, []byte(`{"order_number":"ORD_2","new_status":"cooking"}`))
+	if other.EventID == legacy.EventID {
+		t.Error("different legacy events share an id")
+	}
+}
+
+func TestNewNotificationConsumerSubscription(t *testing.T) {
+	tests := []struct {
+		subscription string
+		valid        bool
+	}{
+		{"", true}, // temporary queue
+		{"sms", true},
+		{"email-eu_2", true},
+		{"0", true},
+		{strings.Repeat("a", 63), true},
+		{strings.Repeat("a", 64), false},
+		{"-sms", false},
+		{"_sms", false},
+		{"SMS", false},
+		{"sms.eu", false},
+		{"sms eu", false},
+		{"sms/eu", false},
+		{"смс", false},
+	}
+
+	for _, tt := range tests {
+		consumer, err := NewNotificationConsumer(nil, tt.subscription)
+		if tt.valid {
+			if err != nil || consumer.subscription != tt.subscription {
+				t.Errorf("NewNotificationConsumer(%q) = %v, %v; want it accepted", tt.subscription, consumer, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("NewNotificationConsumer(%q) accepted an invalid subscription", tt.subscription)
+		}
+	}
+}
+
+func TestRequeueDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{6, maxRequeueDelay},
+		{100, maxRequeueDelay},
+	}
+	for _, tt := range tests {
+		if got := requeueDelay(tt.failures); got != tt.want {
+			t.Errorf("requeueDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+// acknowledger records what the consumer did with a delivery
+type acknowledger struct {
+	acked, rejected bool
+}
+
+func (a *acknowledger) Ack(tag uint64, multiple bool) error { a.acked = true; return nil }
+func (a *acknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.rejected = !requeue
+	return nil
+}
+func (a *acknowledger) Reject(tag uint64, requeue bool) error { a.rejected = !requeue; return nil }
+
+func TestHandle(t *testing.T) {
+	status := `{"event_id":"e1","event_type":"` + events.TypeOrderStatusChanged + `","order_number":"ORD_1","new_status":"ready"}`
+	lowStock := `{"event_id":"e2","event_type":"` + events.TypeLowStock + `","ingredient":"rice"}`
+	down := errors.New("database is down")
+
+	tests := []struct {
+		name         string
+		body         string
+		webhookErr   error
+		statusErr    error
+		wantRequeue  bool
+		wantAcked    bool
+		wantRejected bool
+		wantNotified bool
+	}{
+		{"status notified", status, nil, nil, false, true, false, true},
+		{"webhooks not queued", status, down, nil, true, false, false, false},
+		{"status not recorded", status, nil, down, true, false, false, true},
+		{"low stock", lowStock, nil, nil, false, true, false, true},
+		{"low stock webhooks not queued", lowStock, down, nil, true, false, false, false},
+		{"unknown type", `{"event_type":"order.eaten"}`, nil, nil, false, true, false, false},
+		{"not json", `order ready`, nil, nil, false, false, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ack := &acknowledger{}
+			notified := false
+			handler := func(models.StatusUpdateMessage) error { notified = true; return tt.statusErr }
+			lowStockHandler := func(models.LowStockMessage) { notified = true }
+			eventHandler := func(models.WebhookEvent) error { return tt.webhookErr }
+
+			c := &NotificationConsumer{}
+			err := c.handle(amqp.Delivery{Acknowledger: ack, Body: []byte(tt.body)}, handler, lowStockHandler, eventHandler)
+
+			if (err != nil) != tt.wantRequeue {
+				t.Errorf("err = %v, want requeue %v", err, tt.wantRequeue)
+			}
+			if ack.acked != tt.wantAcked || ack.rejected != tt.wantRejected {
+				t.Errorf("acked %v rejected %v, want %v %v", ack.acked, ack.rejected, tt.wantAcked, tt.wantRejected)
+			}
+			// a requeued event must not have been notified unless its handler
+			// failed before sending, which the status handler guarantees
+			if notified != tt.wantNotified {
+				t.Errorf("handler called %v, want %v", notified, tt.wantNotified)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package rabbitmq
 
 import (
+	"fmt"
+	"restaurant-system/services/notification-service/config"
+
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -9,16 +12,16 @@ type Client struct {
 	channel *amqp.Channel
 }
 
-func NewClient(url string) (*Client, error) {
-	conn, err := amqp.Dial(url)
+func NewClient(cfg config.RabbitMQConfig) (*Client, error) {
+	conn, err := amqp.Dial(cfg.ConnectionString())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
 	ch, err := conn.Channel()
 	if err != nil {
 		conn.Close()
-		return nil, err
+		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
 	return &Client{conn: conn, channel: ch}, nil
@@ -47,6 +50,18 @@ func (c *Client) DeclareQueue(name string) (amqp.Queue, error) {
 	)
 }
 
+// DeclareExclusiveQueue declares a queue that is deleted together with the connection
+func (c *Client) DeclareExclusiveQueue(name string) (amqp.Queue, error) {
+	return c.channel.QueueDeclare(
+		name,
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,
+	)
+}
+
 func (c *Client) BindQueue(queue, exchange, routingKey string) error {
 	return c.channel.QueueBind(
 		queue,
@@ -69,6 +84,11 @@ func (c *Client) Consume(queue, consumer string) (<-chan amqp.Delivery, error) {
 	)
 }
 
+// CancelConsumer stops deliveries to consumer; its delivery channel is closed afterwards
+func (c *Client) CancelConsumer(consumer string) error {
+	return c.channel.Cancel(consumer, false)
+}
+
 func (c *Client) Close() {
 	if c.channel != nil {
 		c.channel.Close()
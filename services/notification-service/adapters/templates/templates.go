@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// files holds one template file per locale and channel: files/<locale>/<channel>.tmpl.
+// Each file defines a "<status>" body and, for email, a "<status>.subject" template,
+// plus "default" templates used for statuses without their own.
+//
+//go:embed files
+var files embed.FS
+
+// DefaultLocale is used when a customer has no locale or one we have no templates for
+const DefaultLocale = "en"
+
+const defaultTemplate = "default"
+
+type Renderer struct {
+	// keyed by "<locale>/<channel>"
+	sets map[string]*template.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	paths, err := fs.Glob(files, "files/*/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	sets := make(map[string]*template.Template, len(paths))
+	for _, p := range paths {
+		locale := path.Base(path.Dir(p))
+		channel := strings.TrimSuffix(path.Base(p), ".tmpl")
+
+		tmpl, err := template.New(channel).Option("missingkey=error").ParseFS(files, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", p, err)
+		}
+		if tmpl.Lookup(defaultTemplate) == nil {
+			return nil, fmt.Errorf("template %s has no %q block", p, defaultTemplate)
+		}
+		sets[locale+"/"+channel] = tmpl
+	}
+
+	return &Renderer{sets: sets}, nil
+}
+
+var _ ports.MessageRenderer = (*Renderer)(nil)
+
+func (r *Renderer) Render(locale, channel string, data models.MessageData) (string, string, error) {
+	tmpl, ok := r.sets[locale+"/"+channel]
+	if !ok {
+		tmpl, ok = r.sets[DefaultLocale+"/"+channel]
+	}
+	if !ok {
+		return "", "", fmt.Errorf("no templates for channel %q", channel)
+	}
+
+	name := data.Status
+	if tmpl.Lookup(name) == nil {
+		name = defaultTemplate
+	}
+
+	body, err := execute(tmpl, name, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	var subject string
+	if tmpl.Lookup(name+".subject") != nil {
+		if subject, err = execute(tmpl, name+".subject", data); err != nil {
+			return "", "", err
+		}
+	}
+
+	return subject, body, nil
+}
+
+// Locales lists the locales that have at least one template
+func (r *Renderer) Locales() []string {
+	seen := make(map[string]bool)
+	var locales []string
+	for key := range r.sets {
+		locale, _, _ := strings.Cut(key, "/")
+		if !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func execute(tmpl *template.Template, name string, data models.MessageData) (string, error) {
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, name, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
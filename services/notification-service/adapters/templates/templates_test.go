@@ -0,0 +1,122 @@
+package templates
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+var (
+	locales  = []string{"en", "ru", "kk"}
+	channels = []string{"email", "sms"}
+	// every order status plus one without its own template, which falls back to "default"
+	statuses = []string{"received", "cooking", "ready", "completed", "cancelled", "delayed"}
+)
+
+func sample(status, eta string) models.MessageData {
+	return models.MessageData{
+		OrderNumber:    "ORD_20261019_001",
+		CustomerName:   "Aruzhan",
+		Status:         status,
+		OldStatus:      "received",
+		Timestamp:      "2026-10-19T12:00:00Z",
+		EstimatedReady: eta,
+	}
+}
+
+func TestRenderEveryTemplate(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	if got := r.Locales(); !slices.Equal(got, []string{"en", "kk", "ru"}) {
+		t.Errorf("Locales = %v, want en, kk, ru", got)
+	}
+
+	for _, locale := range locales {
+		for _, channel := range channels {
+			for _, status := range statuses {
+				t.Run(locale+"/"+channel+"/"+status, func(t *testing.T) {
+					subject, body, err := r.Render(locale, channel, sample(status, "12:45"))
+					if err != nil {
+						t.Fatalf("Render: %v", err)
+					}
+					if !strings.Contains(body, "ORD_20261019_001") && !strings.Contains(body, "Aruzhan") {
+						t.Errorf("body does not mention the order or the customer: %q", body)
+					}
+					for _, text := range []string{subject, body} {
+						if strings.Contains(text, "<no value>") || strings.Contains(text, "{{") {
+							t.Errorf("unfilled placeholder in %q", text)
+						}
+					}
+					if (channel == "email") != (subject != "") {
+						t.Errorf("subject %q for channel %s", subject, channel)
+					}
+					if subject != "" && !strings.Contains(subject, "ORD_20261019_001") {
+						t.Errorf("subject %q does not name the order", subject)
+					}
+					if status == "delayed" && !strings.Contains(body, "delayed") {
+						t.Errorf("default template does not show the status: %q", body)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestRenderETA(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	for _, locale := range locales {
+		for _, channel := range channels {
+			for _, status := range []string{"received", "cooking"} {
+				_, withETA, err := r.Render(locale, channel, sample(status, "12:45"))
+				if err != nil {
+					t.Fatalf("Render: %v", err)
+				}
+				_, withoutETA, err := r.Render(locale, channel, sample(status, ""))
+				if err != nil {
+					t.Fatalf("Render: %v", err)
+				}
+				if !strings.Contains(withETA, "12:45") {
+					t.Errorf("%s/%s/%s: ETA missing from %q", locale, channel, status, withETA)
+				}
+				if len(withoutETA) >= len(withETA) {
+					t.Errorf("%s/%s/%s: no ETA should drop the ETA sentence, got %q", locale, channel, status, withoutETA)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	for _, channel := range channels {
+		wantSubject, wantBody, err := r.Render(DefaultLocale, channel, sample("ready", ""))
+		if err != nil {
+			t.Fatalf("Render: %v", err)
+		}
+		for _, locale := range []string{"", "de", "EN"} {
+			subject, body, err := r.Render(locale, channel, sample("ready", ""))
+			if err != nil {
+				t.Fatalf("Render(%q): %v", locale, err)
+			}
+			if subject != wantSubject || body != wantBody {
+				t.Errorf("locale %q/%s rendered %q %q, want the %s templates", locale, channel, subject, body, DefaultLocale)
+			}
+		}
+	}
+
+	if _, _, err := r.Render("en", "push", sample("ready", "")); err == nil {
+		t.Error("a channel without templates should fail")
+	}
+}
@@ -0,0 +1,57 @@
+package notificationservice
+
+import (
+	"fmt"
+	"io"
+	"restaurant-system/services/notification-service/adapters/templates"
+	"restaurant-system/services/notification-service/domain/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+type PreviewConfig struct {
+	Locale  string
+	Channel string
+	Status  string
+}
+
+// статус, из которого заказ обычно приходит в данный
+var previousStatus = map[string]string{
+	"cooking":   "received",
+	"ready":     "cooking",
+	"completed": "ready",
+	"cancelled": "received",
+}
+
+// Preview рендерит шаблон уведомления на примере заказа, без RabbitMQ и PostgreSQL
+func Preview(w io.Writer, cfg PreviewConfig) error {
+	renderer, err := templates.NewRenderer()
+	if err != nil {
+		return fmt.Errorf("failed to load notification templates: %w", err)
+	}
+	if locales := renderer.Locales(); !slices.Contains(locales, cfg.Locale) {
+		return fmt.Errorf("unknown locale %q, available: %s", cfg.Locale, strings.Join(locales, ", "))
+	}
+
+	now := time.Now()
+	sample := models.MessageData{
+		OrderNumber:    "ORD_" + now.Format("20060102") + "_001",
+		CustomerName:   "Aruzhan",
+		Status:         cfg.Status,
+		OldStatus:      previousStatus[cfg.Status],
+		Timestamp:      now.UTC().Format(time.RFC3339),
+		EstimatedReady: now.Add(15 * time.Minute).Format("15:04"),
+	}
+
+	subject, body, err := renderer.Render(cfg.Locale, cfg.Channel, sample)
+	if err != nil {
+		return err
+	}
+
+	if subject != "" {
+		fmt.Fprintf(w, "Subject: %s\n\n", subject)
+	}
+	fmt.Fprintln(w, body)
+	return nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type DatabaseConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string
+}
+
+type RabbitMQConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+}
+
+// NotifierConfig selects and configures the channels customers are notified through
+type NotifierConfig struct {
+	// Channels is NOTIFY_CHANNELS, a comma-separated subset of file, smtp, webhook, sms
+	Channels []string
+
+	// FilePath is where the file channel appends JSON lines; "-" or "stdout" means standard output
+	FilePath string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUser     string
+	SMTPPassword string
+	SMTPFrom     string
+
+	WebhookURL string
+
+	SMSGatewayURL   string
+	SMSGatewayToken string
+	SMSSender       string
+}
+
+type Config struct {
+	Database  DatabaseConfig
+	RabbitMQ  RabbitMQConfig
+	Notifiers NotifierConfig
+	// Timezone is NOTIFY_TIMEZONE, the IANA zone (e.g. Asia/Almaty) customer messages
+	// show clock times in; empty means the server's local zone
+	Timezone string
+}
+
+func LoadConfig() (*Config, error) {
+	config := &Config{
+		Database: DatabaseConfig{
+			Host:     getEnv("DB_HOST", "localhost"),
+			Port:     getEnvAsInt("DB_PORT", 5432),
+			User:     getEnv("DB_USER", "restaurant_user"),
+			Password: getEnv("DB_PASSWORD", "restaurant_pass"),
+			Database: getEnv("DB_NAME", "restaurant_db"),
+		},
+		RabbitMQ: RabbitMQConfig{
+			Host:     getEnv("RABBITMQ_HOST", "localhost"),
+			Port:     getEnvAsInt("RABBITMQ_PORT", 5672),
+			User:     getEnv("RABBITMQ_USER", "guest"),
+			Password: getEnv("RABBITMQ_PASSWORD", "guest"),
+		},
+		Notifiers: NotifierConfig{
+			Channels:        getEnvAsList("NOTIFY_CHANNELS"),
+			FilePath:        getEnv("NOTIFY_FILE_PATH", "stdout"),
+			SMTPHost:        getEnv("SMTP_HOST", ""),
+			SMTPPort:        getEnvAsInt("SMTP_PORT", 587),
+			SMTPUser:        getEnv("SMTP_USER", ""),
+			SMTPPassword:    getEnv("SMTP_PASSWORD", ""),
+			SMTPFrom:        getEnv("SMTP_FROM", ""),
+			WebhookURL:      getEnv("NOTIFY_WEBHOOK_URL", ""),
+			SMSGatewayURL:   getEnv("SMS_GATEWAY_URL", ""),
+			SMSGatewayToken: getEnv("SMS_GATEWAY_TOKEN", ""),
+			SMSSender:       getEnv("SMS_SENDER", ""),
+		},
+		Timezone: getEnv("NOTIFY_TIMEZONE", ""),
+	}
+
+	return config, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (c *DatabaseConfig) ConnectionString() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		c.User, c.Password, c.Host, c.Port, c.Database)
+}
+
+func (c *RabbitMQConfig) ConnectionString() string {
+	return fmt.Sprintf("amqp://%s:%s@%s:%d/",
+		c.User, c.Password, c.Host, c.Port)
+}
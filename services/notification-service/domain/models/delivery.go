@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Delivery statuses in notification_deliveries
+const (
+	DeliveryPending = "pending" // failed so far, retried at NextAttemptAt
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"  // gave up after the last attempt
+	DeliverySkipped = "skipped" // the channel had nobody to deliver to
+)
+
+// Delivery is one notification on one channel and the outcome of trying to send it
+type Delivery struct {
+	ID            int
+	OrderNumber   string
+	OrderStatus   string
+	Channel       string
+	Recipient     string
+	Notification  Notification
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt *time.Time
+	DeliveredAt   *time.Time
+}
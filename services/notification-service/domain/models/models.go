@@ -1,20 +1,88 @@
 package models
 
-// StatusUpdateMessage represents the message format sent by Kitchen Workers
+import (
+	"errors"
+	"time"
+)
+
+// StatusUpdateMessage is an order status change decoded from events.OrderStatusChanged
 type StatusUpdateMessage struct {
-	OrderNumber    string  `json:"order_number"`
-	OldStatus      string  `json:"old_status"`
-	NewStatus      string  `json:"new_status"`
-	ChangedBy      string  `json:"changed_by"`
-	Timestamp      string  `json:"timestamp"`
-	EstimatedReady *string `json:"estimated_ready,omitempty"`
+	EventID       string
+	CorrelationID string
+	OrderNumber   string
+	OldStatus     string
+	NewStatus     string
+	ChangedBy     string
+	Timestamp     string
+	// EstimatedCompletion is nil when the publisher had no estimate
+	EstimatedCompletion *time.Time
 }
 
-// Notification represents a formatted notification for display
+// Notification represents a formatted notification for display and delivery
 type Notification struct {
 	OrderNumber string `json:"order_number"`
 	OldStatus   string `json:"old_status"`
 	NewStatus   string `json:"new_status"`
 	ChangedBy   string `json:"changed_by"`
+	Subject     string `json:"subject"`
 	Message     string `json:"message"`
+	Timestamp   string `json:"timestamp"`
+
+	// Recipient contacts; channels that need one skip the notification when it is empty
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// MessageData is what customer-facing templates can refer to
+type MessageData struct {
+	OrderNumber    string
+	CustomerName   string
+	Status         string
+	OldStatus      string
+	Timestamp      string
+	EstimatedReady string
+}
+
+// ErrNoRecipient is returned by channels that have nobody to deliver to
+var ErrNoRecipient = errors.New("notification has no recipient for this channel")
+
+// LowStockMessage is published by Kitchen Workers when an ingredient crosses its threshold
+type LowStockMessage struct {
+	Ingredient        string
+	Unit              string
+	StockQuantity     float64
+	LowStockThreshold float64
+	OrderNumber       string
+	Timestamp         string
+}
+
+// Recipient is the customer behind an order and what they asked to be notified about
+type Recipient struct {
+	CustomerName string
+	Email        string
+	Phone        string
+	Channels     []string
+	Statuses     []string
+	// Locale picks the template language (en, ru, kk)
+	Locale string
+}
+
+// WantsStatus reports whether the customer asked to hear about this status
+func (r *Recipient) WantsStatus(status string) bool {
+	for _, s := range r.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// WantsChannel reports whether the customer allowed this customer-facing channel (email, sms)
+func (r *Recipient) WantsChannel(channel string) bool {
+	for _, c := range r.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
 }
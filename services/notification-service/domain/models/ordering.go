@@ -0,0 +1,12 @@
+package models
+
+// EventDecision says whether a status event should produce notifications
+type EventDecision int
+
+const (
+	EventAccepted EventDecision = iota
+	// EventDuplicate is an event id that was already handled
+	EventDuplicate
+	// EventOutOfOrder is a status the order has already passed or been notified about
+	EventOutOfOrder
+)
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// WebhookEvent is an event from notifications_fanout as it is forwarded to
+// webhook subscribers: the original JSON with its envelope
+type WebhookEvent struct {
+	EventID   string
+	EventType string
+	Payload   []byte
+}
+
+// WebhookDelivery is one event for one subscription in webhook_deliveries;
+// statuses are the Delivery* constants except DeliverySkipped
+type WebhookDelivery struct {
+	ID             int
+	SubscriptionID int
+	URL            string
+	Secret         string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseStatus *int
+	LastError      string
+	NextAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+}
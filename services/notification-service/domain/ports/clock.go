@@ -0,0 +1,11 @@
+package ports
+
+import "time"
+
+// Clock is the service's time source, shared with the other services' --time-scale
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	Scale() float64
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"restaurant-system/services/notification-service/domain/models"
+)
+
+// Notifier delivers a notification over one channel (email, SMS, webhook, ...)
+type Notifier interface {
+	// Channel is the name used in NOTIFY_CHANNELS
+	Channel() string
+	// CustomerChannel is the preference value (email, sms) this channel serves,
+	// or "" for operator channels that receive every notification
+	CustomerChannel() string
+	Send(ctx context.Context, notification models.Notification) error
+}
+
+// WebhookSender POSTs a signed event to a subscriber
+type WebhookSender interface {
+	// Send returns the HTTP status code when the subscriber answered (0 otherwise)
+	// and an error for anything but a 2xx answer
+	Send(ctx context.Context, delivery models.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "restaurant-system/services/notification-service/domain/models"
+
+// MessageRenderer turns a status update into the text a customer reads
+type MessageRenderer interface {
+	// Render returns the subject (empty for channels without one) and body
+	// for a locale and customer channel (email, sms)
+	Render(locale, channel string, data models.MessageData) (subject, body string, err error)
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"restaurant-system/services/notification-service/domain/models"
+	"time"
+)
+
+type RecipientRepository interface {
+	// GetRecipient returns nil when the order has no contact details or preferences
+	GetRecipient(ctx context.Context, orderNumber string) (*models.Recipient, error)
+}
+
+type DeliveryRepository interface {
+	// ClaimDue returns pending deliveries whose next attempt is due and pushes their
+	// next_attempt_at forward by lease, so concurrent subscribers do not retry them twice
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Delivery, error)
+	// Update saves the outcome of a send
+	Update(ctx context.Context, delivery *models.Delivery) error
+}
+
+// EventStateRepository remembers handled events and each order's last notified status
+type EventStateRepository interface {
+	// Accept records eventID (if any) and moves the order's last notified status to
+	// status when the current one is in predecessors; nil predecessors skip the ordering check.
+	// An accepted event's deliveries are inserted in the same transaction, so an event
+	// is never marked handled without its notifications on record.
+	Accept(ctx context.Context, eventID, orderNumber, status string, predecessors []string, deliveries []*models.Delivery) (models.EventDecision, error)
+	// PruneEvents forgets event ids handled before the given time
+	PruneEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookRepository queues events for the webhook subscriptions managed by order-service
+type WebhookRepository interface {
+	// Enqueue adds a pending delivery for every active subscription to the event's
+	// type and returns how many were added; redelivered events add none
+	Enqueue(ctx context.Context, event models.WebhookEvent) (int64, error)
+	// ClaimDue works like DeliveryRepository.ClaimDue, with each subscription's URL and secret
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *models.WebhookDelivery) error
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"time"
+)
+
+const (
+	// attempts before a delivery is marked failed
+	maxDeliveryAttempts = 6
+	// first retry delay, doubled after each failure up to maxRetryDelay
+	baseRetryDelay = 30 * time.Second
+	maxRetryDelay  = 30 * time.Minute
+
+	retryPollInterval = 10 * time.Second
+	retryBatchSize    = 50
+	// a claimed delivery is not picked up again for this long, even if this subscriber dies mid-send
+	retryLease = 5 * time.Minute
+)
+
+// newDelivery is a pending delivery leased like a claimed retry, so a subscriber
+// that dies mid-send leaves it for the retry loop instead of losing the notification
+func (s *NotificationService) newDelivery(notifier ports.Notifier, notification models.Notification) *models.Delivery {
+	leaseEnd := s.clock.Now().Add(retryLease)
+	return &models.Delivery{
+		OrderNumber:   notification.OrderNumber,
+		OrderStatus:   notification.NewStatus,
+		Channel:       notifier.Channel(),
+		Recipient:     recipientFor(notifier, notification),
+		Notification:  notification,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: &leaseEnd,
+	}
+}
+
+// deliver sends a pending delivery written when the event was accepted and saves the outcome
+func (s *NotificationService) deliver(notifier ports.Notifier, delivery *models.Delivery) {
+	s.attempt(notifier, delivery)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		log.Printf("Failed to update %s delivery for order %s: %v", delivery.Channel, delivery.OrderNumber, err)
+	}
+}
+
+// attempt sends the delivery's notification once and moves it to its next state
+func (s *NotificationService) attempt(notifier ports.Notifier, delivery *models.Delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	err := notifier.Send(ctx, delivery.Notification)
+	cancel()
+
+	delivery.Attempts++
+	now := s.clock.Now()
+
+	switch {
+	case errors.Is(err, models.ErrNoRecipient):
+		log.Printf("Skipping %s notification for order %s: no recipient", delivery.Channel, delivery.OrderNumber)
+		delivery.Status = models.DeliverySkipped
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = nil
+	case err != nil:
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= maxDeliveryAttempts {
+			log.Printf("Giving up on %s notification for order %s after %d attempts: %v", delivery.Channel, delivery.OrderNumber, delivery.Attempts, err)
+			delivery.Status = models.DeliveryFailed
+			delivery.NextAttemptAt = nil
+			return
+		}
+		next := now.Add(retryDelay(delivery.Attempts))
+		log.Printf("Failed to send %s notification for order %s (attempt %d, retry at %s): %v",
+			delivery.Channel, delivery.OrderNumber, delivery.Attempts, next.Format(time.RFC3339), err)
+		delivery.Status = models.DeliveryPending
+		delivery.NextAttemptAt = &next
+	default:
+		log.Printf("Sent %s notification for order %s", delivery.Channel, delivery.OrderNumber)
+		delivery.Status = models.DeliverySent
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	}
+}
+
+// RunRetries re-sends pending deliveries whose backoff has elapsed until ctx is cancelled
+func (s *NotificationService) RunRetries(ctx context.Context) {
+	ticker := time.NewTicker(retryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.retryDue(ctx)
+		}
+	}
+}
+
+func (s *NotificationService) retryDue(ctx context.Context) {
+	deliveries, err := s.deliveries.ClaimDue(ctx, retryBatchSize, retryLease)
+	if err != nil {
+		log.Printf("Failed to load notification retries: %v", err)
+		return
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		notifier := s.notifierByChannel(delivery.Channel)
+		if notifier == nil {
+			// the channel was removed from NOTIFY_CHANNELS since the first attempt
+			delivery.Status = models.DeliveryFailed
+			delivery.LastError = "channel " + delivery.Channel + " is no longer configured"
+			delivery.NextAttemptAt = nil
+		} else {
+			s.attempt(notifier, delivery)
+		}
+
+		if err := s.deliveries.Update(ctx, delivery); err != nil {
+			log.Printf("Failed to update %s delivery for order %s: %v", delivery.Channel, delivery.OrderNumber, err)
+		}
+	}
+}
+
+func (s *NotificationService) notifierByChannel(channel string) ports.Notifier {
+	for _, notifier := range s.notifiers {
+		if notifier.Channel() == channel {
+			return notifier
+		}
+	}
+	return nil
+}
+
+// retryDelay doubles the delay with every failed attempt
+func retryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// recipientFor is the address a customer channel delivers to; operator channels have none
+func recipientFor(notifier ports.Notifier, notification models.Notification) string {
+	switch notifier.CustomerChannel() {
+	case "email":
+		return notification.Email
+	case "sms":
+		return notification.Phone
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"restaurant-system/shared/clock"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{5, 8 * time.Minute},
+		{6, 16 * time.Minute},
+		{7, maxRetryDelay},
+		{50, maxRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// failingNotifier answers every send with err
+type failingNotifier struct {
+	channel string
+	err     error
+}
+
+func (n failingNotifier) Channel() string         { return n.channel }
+func (n failingNotifier) CustomerChannel() string { return "" }
+func (n failingNotifier) Send(ctx context.Context, notification models.Notification) error {
+	return n.err
+}
+
+func TestAttempt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { next := now.Add(d); return &next }
+
+	tests := []struct {
+		name          string
+		err           error
+		attempts      int // before this attempt
+		wantStatus    string
+		wantNext      *time.Time
+		wantError     string
+		wantDelivered bool
+	}{
+		{"sent", nil, 0, models.DeliverySent, nil, "", true},
+		{"sent on a retry clears the error", nil, 3, models.DeliverySent, nil, "", true},
+		{"first failure backs off", errors.New("relay down"), 0, models.DeliveryPending, at(30 * time.Second), "relay down", false},
+		{"later failure backs off longer", errors.New("relay down"), 3, models.DeliveryPending, at(4 * time.Minute), "relay down", false},
+		{"last failure gives up", errors.New("relay down"), maxDeliveryAttempts - 1, models.DeliveryFailed, nil, "relay down", false},
+		{"no recipient is skipped", models.ErrNoRecipient, 0, models.DeliverySkipped, nil, models.ErrNoRecipient.Error(), false},
+		{"wrapped no recipient is skipped", fmt.Errorf("sms: %w", models.ErrNoRecipient), 2, models.DeliverySkipped, nil, "sms: " + models.ErrNoRecipient.Error(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewNotificationService(nil, noRecipients{}, &memoryDeliveries{}, nil, nil, time.UTC, clock.NewManual(now))
+			delivery := &models.Delivery{
+				OrderNumber:   "ORD_1",
+				Channel:       "smtp",
+				Status:        models.DeliveryPending,
+				Attempts:      tt.attempts,
+				LastError:     "earlier failure",
+				NextAttemptAt: at(retryLease),
+			}
+
+			s.attempt(failingNotifier{channel: "smtp", err: tt.err}, delivery)
+
+			if delivery.Attempts != tt.attempts+1 {
+				t.Errorf("Attempts = %d, want %d", delivery.Attempts, tt.attempts+1)
+			}
+			if delivery.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", delivery.Status, tt.wantStatus)
+			}
+			if (delivery.NextAttemptAt == nil) != (tt.wantNext == nil) ||
+				(tt.wantNext != nil && !delivery.NextAttemptAt.Equal(*tt.wantNext)) {
+				t.Errorf("NextAttemptAt = %v, want %v", delivery.NextAttemptAt, tt.wantNext)
+			}
+			if delivery.LastError != tt.wantError {
+				t.Errorf("LastError = %q, want %q", delivery.LastError, tt.wantError)
+			}
+			if (delivery.DeliveredAt != nil) != tt.wantDelivered || (tt.wantDelivered && !delivery.DeliveredAt.Equal(now)) {
+				t.Errorf("DeliveredAt = %v, want delivered %v at %v", delivery.DeliveredAt, tt.wantDelivered, now)
+			}
+		})
+	}
+}
+
+func TestRetryDue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	deliveries := &memoryDeliveries{due: []models.Delivery{
+		{ID: 1, OrderNumber: "ORD_1", Channel: "log", Status: models.DeliveryPending, Attempts: 1, Notification: models.Notification{NewStatus: "ready"}},
+		{ID: 2, OrderNumber: "ORD_2", Channel: "pager", Status: models.DeliveryPending, Attempts: 2},
+		{ID: 3, OrderNumber: "ORD_3", Channel: "smtp", Status: models.DeliveryPending, Attempts: 1},
+	}}
+	notifier := &recordingNotifier{}
+	s := NewNotificationService([]ports.Notifier{notifier, failingNotifier{channel: "smtp", err: errors.New("relay down")}},
+		noRecipients{}, deliveries, nil, nil, time.UTC, clock.NewManual(now))
+
+	s.retryDue(context.Background())
+
+	if len(deliveries.updated) != 3 {
+		t.Fatalf("updated %d deliveries, want 3", len(deliveries.updated))
+	}
+	sent, removed, failed := deliveries.updated[0], deliveries.updated[1], deliveries.updated[2]
+
+	if sent.Status != models.DeliverySent || sent.Attempts != 2 || len(notifier.sent) != 1 {
+		t.Errorf("log delivery %+v sent %v, want it sent on attempt 2", sent, notifier.sent)
+	}
+
+	// pager was dropped from NOTIFY_CHANNELS since the first attempt
+	if removed.Status != models.DeliveryFailed || removed.NextAttemptAt != nil || removed.Attempts != 2 ||
+		removed.LastError != "channel pager is no longer configured" {
+		t.Errorf("removed channel delivery = %+v, want failed without another attempt", removed)
+	}
+
+	if failed.Status != models.DeliveryPending || failed.Attempts != 2 || failed.NextAttemptAt == nil ||
+		!failed.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("smtp delivery = %+v, want pending again in a minute", failed)
+	}
+}
@@ -1,26 +1,74 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
 	"time"
 )
 
-type NotificationService struct{}
+// how long one channel may take to deliver a notification
+const sendTimeout = 10 * time.Second
 
-func NewNotificationService() *NotificationService {
-	return &NotificationService{}
+type NotificationService struct {
+	notifiers  []ports.Notifier
+	recipients ports.RecipientRepository
+	deliveries ports.DeliveryRepository
+	events     ports.EventStateRepository
+	renderer   ports.MessageRenderer
+	// location is the time zone customer messages show the ETA in
+	location *time.Location
+	clock    ports.Clock
 }
 
-func (s *NotificationService) HandleStatusUpdate(update models.StatusUpdateMessage) {
+func NewNotificationService(notifiers []ports.Notifier, recipients ports.RecipientRepository, deliveries ports.DeliveryRepository, events ports.EventStateRepository, renderer ports.MessageRenderer, location *time.Location, clock ports.Clock) *NotificationService {
+	return &NotificationService{
+		notifiers:  notifiers,
+		recipients: recipients,
+		deliveries: deliveries,
+		events:     events,
+		renderer:   renderer,
+		location:   location,
+		clock:      clock,
+	}
+}
+
+// HandleStatusUpdate notifies every channel about the update. It fails when the
+// update could not be prepared or recorded, so that the event is delivered again
+// and retried; nothing has been sent by then.
+func (s *NotificationService) HandleStatusUpdate(update models.StatusUpdateMessage) error {
 	// Create formatted notification message
 	notification := models.Notification{
 		OrderNumber: update.OrderNumber,
 		OldStatus:   update.OldStatus,
 		NewStatus:   update.NewStatus,
 		ChangedBy:   update.ChangedBy,
+		Subject:     "Order " + update.OrderNumber + " is " + update.NewStatus,
 		Message:     s.formatNotificationMessage(update),
+		Timestamp:   update.Timestamp,
+	}
+
+	// Prepare every channel's delivery first: accepting the event writes them
+	// in the same transaction that marks it processed
+	outgoing, err := s.prepare(notification, update)
+	if err != nil {
+		return err
+	}
+	deliveries := make([]*models.Delivery, len(outgoing))
+	for i := range outgoing {
+		deliveries[i] = outgoing[i].delivery
+	}
+
+	// Drop redelivered and out-of-sequence events
+	accepted, err := s.accept(update, deliveries)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return nil
 	}
 
 	// Print to console (human-readable)
@@ -28,15 +76,82 @@ func (s *NotificationService) HandleStatusUpdate(update models.StatusUpdateMessa
 
 	// Also log in structured JSON format
 	s.logStructuredNotification(update)
+
+	// Deliver through every configured channel
+	for _, o := range outgoing {
+		s.deliver(o.notifier, o.delivery)
+	}
+	return nil
 }
 
+// outgoing is one channel's delivery of a status update
+type outgoing struct {
+	notifier ports.Notifier
+	delivery *models.Delivery
+}
+
+// prepare builds a delivery for operator channels always and for customer channels
+// only for the statuses and channels the customer chose, in their locale.
+// Only customer channels get the customer's email and phone. A failed recipient
+// lookup fails the whole update: sending it to operators alone would mark the
+// event handled and the customer would never hear about it.
+func (s *NotificationService) prepare(notification models.Notification, update models.StatusUpdateMessage) ([]outgoing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	recipient, err := s.recipients.GetRecipient(ctx, notification.OrderNumber)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up recipient for order %s: %w", notification.OrderNumber, err)
+	}
+
+	var prepared []outgoing
+	for _, notifier := range s.notifiers {
+		message := notification
+		if channel := notifier.CustomerChannel(); channel != "" {
+			if recipient == nil || !recipient.WantsChannel(channel) || !recipient.WantsStatus(notification.NewStatus) {
+				continue
+			}
+			subject, body, err := s.renderer.Render(recipient.Locale, channel, messageData(update, recipient, s.location))
+			if err != nil {
+				log.Printf("Failed to render %s notification for order %s: %v", notifier.Channel(), notification.OrderNumber, err)
+				continue
+			}
+			if subject != "" {
+				message.Subject = subject
+			}
+			message.Message = body
+			message.Email = recipient.Email
+			message.Phone = recipient.Phone
+		}
+
+		prepared = append(prepared, outgoing{notifier: notifier, delivery: s.newDelivery(notifier, message)})
+	}
+	return prepared, nil
+}
+
+// messageData is the template view of an update. The ETA is shown as a clock time
+// in location, which should be the restaurant's zone rather than the server's.
+func messageData(update models.StatusUpdateMessage, recipient *models.Recipient, location *time.Location) models.MessageData {
+	data := models.MessageData{
+		OrderNumber:  update.OrderNumber,
+		CustomerName: recipient.CustomerName,
+		Status:       update.NewStatus,
+		OldStatus:    update.OldStatus,
+		Timestamp:    update.Timestamp,
+	}
+	if update.EstimatedCompletion != nil {
+		data.EstimatedReady = update.EstimatedCompletion.In(location).Format("15:04")
+	}
+	return data
+}
+
+// formatNotificationMessage is the operator-facing text for log, file and webhook channels
 func (s *NotificationService) formatNotificationMessage(update models.StatusUpdateMessage) string {
 	message := "Notification for order " + update.OrderNumber +
 		": Status changed from '" + update.OldStatus +
 		"' to '" + update.NewStatus + "' by " + update.ChangedBy
 
-	if update.EstimatedReady != nil {
-		message += ". Estimated ready: " + *update.EstimatedReady
+	if update.EstimatedCompletion != nil {
+		message += ". Estimated ready: " + update.EstimatedCompletion.Format(time.RFC3339)
 	}
 
 	return message
@@ -49,7 +164,7 @@ func (s *NotificationService) printHumanReadableNotification(notification models
 func (s *NotificationService) logStructuredNotification(update models.StatusUpdateMessage) {
 	// Structured JSON log
 	logData := map[string]interface{}{
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"timestamp": s.clock.Now().UTC().Format(time.RFC3339),
 		"level":     "DEBUG",
 		"service":   "notification-subscriber",
 		"action":    "notification_received",
@@ -66,3 +181,27 @@ func (s *NotificationService) logStructuredNotification(update models.StatusUpda
 		log.Println(string(jsonData))
 	}
 }
+
+func (s *NotificationService) HandleLowStock(alert models.LowStockMessage) {
+	log.Printf("LOW STOCK: %s is down to %.3f %s (threshold %.3f)",
+		alert.Ingredient, alert.StockQuantity, alert.Unit, alert.LowStockThreshold)
+
+	logData := map[string]interface{}{
+		"timestamp": s.clock.Now().UTC().Format(time.RFC3339),
+		"level":     "INFO",
+		"service":   "notification-subscriber",
+		"action":    "low_stock_received",
+		"message":   "Ingredient " + alert.Ingredient + " is running low",
+		"details": map[string]interface{}{
+			"ingredient":          alert.Ingredient,
+			"unit":                alert.Unit,
+			"stock_quantity":      alert.StockQuantity,
+			"low_stock_threshold": alert.LowStockThreshold,
+			"order_number":        alert.OrderNumber,
+		},
+	}
+
+	if jsonData, err := json.Marshal(logData); err == nil {
+		log.Println(string(jsonData))
+	}
+}
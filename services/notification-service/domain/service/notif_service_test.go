@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"restaurant-system/shared/clock"
+)
+
+type fixedRecipient struct {
+	recipient *models.Recipient
+	err       error
+}
+
+func (f fixedRecipient) GetRecipient(ctx context.Context, orderNumber string) (*models.Recipient, error) {
+	return f.recipient, f.err
+}
+
+type plainRenderer struct{}
+
+func (plainRenderer) Render(locale, channel string, data models.MessageData) (string, string, error) {
+	return "Order " + data.OrderNumber, locale + " " + channel + " " + data.Status, nil
+}
+
+// capturingNotifier remembers every notification it was asked to send
+type capturingNotifier struct {
+	channel         string
+	customerChannel string
+	sent            []models.Notification
+}
+
+func (n *capturingNotifier) Channel() string         { return n.channel }
+func (n *capturingNotifier) CustomerChannel() string { return n.customerChannel }
+func (n *capturingNotifier) Send(ctx context.Context, notification models.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestHandleStatusUpdateContactsOnlyForCustomers(t *testing.T) {
+	email := &capturingNotifier{channel: "smtp", customerChannel: "email"}
+	sms := &capturingNotifier{channel: "sms", customerChannel: "sms"}
+	file := &capturingNotifier{channel: "file"}
+	webhook := &capturingNotifier{channel: "webhook"}
+
+	recipients := fixedRecipient{recipient: &models.Recipient{
+		CustomerName: "Alice",
+		Email:        "alice@example.com",
+		Phone:        "+77011234567",
+		Channels:     []string{"email", "sms"},
+		Statuses:     []string{"ready"},
+		Locale:       "en",
+	}}
+	events := &memoryEvents{processed: map[string]bool{}, last: map[string]string{}}
+	clk := clock.NewManual(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	s := NewNotificationService([]ports.Notifier{email, sms, file, webhook}, recipients, &memoryDeliveries{}, events, plainRenderer{}, time.UTC, clk)
+
+	if err := s.HandleStatusUpdate(models.StatusUpdateMessage{EventID: "e1", OrderNumber: "ORD_1", OldStatus: "cooking", NewStatus: "ready"}); err != nil {
+		t.Fatalf("HandleStatusUpdate: %v", err)
+	}
+
+	tests := []struct {
+		notifier    *capturingNotifier
+		wantEmail   string
+		wantPhone   string
+		wantMessage string
+	}{
+		{email, "alice@example.com", "+77011234567", "en email ready"},
+		{sms, "alice@example.com", "+77011234567", "en sms ready"},
+		{file, "", "", "Notification for order ORD_1: Status changed from 'cooking' to 'ready' by "},
+		{webhook, "", "", "Notification for order ORD_1: Status changed from 'cooking' to 'ready' by "},
+	}
+	for _, tt := range tests {
+		if len(tt.notifier.sent) != 1 {
+			t.Errorf("%s sent %d notifications, want 1", tt.notifier.channel, len(tt.notifier.sent))
+			continue
+		}
+		got := tt.notifier.sent[0]
+		if got.Email != tt.wantEmail || got.Phone != tt.wantPhone || got.Message != tt.wantMessage {
+			t.Errorf("%s got email %q phone %q message %q, want %q %q %q",
+				tt.notifier.channel, got.Email, got.Phone, got.Message, tt.wantEmail, tt.wantPhone, tt.wantMessage)
+		}
+	}
+}
+
+func TestHandleStatusUpdateRecipientLookupFails(t *testing.T) {
+	file := &capturingNotifier{channel: "file"}
+	events := &memoryEvents{processed: map[string]bool{}, last: map[string]string{}}
+	clk := clock.NewManual(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	s := NewNotificationService([]ports.Notifier{file}, fixedRecipient{err: errors.New("database is down")}, &memoryDeliveries{}, events, plainRenderer{}, time.UTC, clk)
+
+	update := models.StatusUpdateMessage{EventID: "e1", OrderNumber: "ORD_1", NewStatus: "ready"}
+	if err := s.HandleStatusUpdate(update); err == nil {
+		t.Fatal("HandleStatusUpdate should fail so the event is redelivered")
+	}
+	// nothing was sent or recorded, so the redelivered event is not a duplicate
+	if len(file.sent) != 0 || events.processed["e1"] {
+		t.Errorf("sent %d notifications, event recorded %v; want neither", len(file.sent), events.processed["e1"])
+	}
+
+	s.recipients = noRecipients{}
+	if err := s.HandleStatusUpdate(update); err != nil {
+		t.Fatalf("redelivered HandleStatusUpdate: %v", err)
+	}
+	if len(file.sent) != 1 {
+		t.Errorf("redelivery sent %d notifications, want 1", len(file.sent))
+	}
+}
+
+func TestMessageDataETAInLocation(t *testing.T) {
+	eta := time.Date(2026, 10, 19, 7, 45, 0, 0, time.UTC)
+	almaty := time.FixedZone("Asia/Almaty", 5*60*60)
+	update := models.StatusUpdateMessage{OrderNumber: "ORD_1", NewStatus: "cooking", EstimatedCompletion: &eta}
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		want     string
+	}{
+		{"utc", time.UTC, "07:45"},
+		{"restaurant zone", almaty, "12:45"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := messageData(update, &models.Recipient{CustomerName: "Alice"}, tt.location)
+			if data.EstimatedReady != tt.want {
+				t.Errorf("EstimatedReady = %q, want %q", data.EstimatedReady, tt.want)
+			}
+		})
+	}
+
+	update.EstimatedCompletion = nil
+	if data := messageData(update, &models.Recipient{}, time.UTC); data.EstimatedReady != "" {
+		t.Errorf("EstimatedReady = %q without an estimate", data.EstimatedReady)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"restaurant-system/services/notification-service/domain/models"
+	"time"
+)
+
+const (
+	// how long handled event ids are remembered for deduplication
+	processedEventRetention = 7 * 24 * time.Hour
+	pruneInterval           = time.Hour
+)
+
+// statusPredecessors lists, for each status of the order state machine
+// (received → cooking → ready → completed, cancelled from any open status),
+// the last notified statuses it may follow. A status never follows itself,
+// so a republished "cooking" is dropped, and a late "cooking" after "ready" is too.
+var statusPredecessors = map[string][]string{
+	"received":  {},
+	"cooking":   {"received"},
+	"ready":     {"received", "cooking"},
+	"completed": {"received", "cooking", "ready"},
+	"cancelled": {"received", "cooking", "ready"},
+}
+
+// accept decides whether the update should be notified and, if so, records the
+// update together with its pending deliveries. It fails when the state store is
+// unavailable: an update notified without a record could not be deduplicated
+// or ordered, and its deliveries would be invisible to the retry loop.
+func (s *NotificationService) accept(update models.StatusUpdateMessage, deliveries []*models.Delivery) (bool, error) {
+	predecessors, known := statusPredecessors[update.NewStatus]
+	if !known {
+		// statuses outside the state machine are passed on untracked
+		predecessors = nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	decision, err := s.events.Accept(ctx, update.EventID, update.OrderNumber, update.NewStatus, predecessors, deliveries)
+	if err != nil {
+		return false, fmt.Errorf("failed to check event order for order %s: %w", update.OrderNumber, err)
+	}
+
+	switch decision {
+	case models.EventDuplicate:
+		log.Printf("Dropping duplicate event %s for order %s", update.EventID, update.OrderNumber)
+		return false, nil
+	case models.EventOutOfOrder:
+		log.Printf("Dropping out-of-order %s event for order %s", update.NewStatus, update.OrderNumber)
+		return false, nil
+	}
+	return true, nil
+}
+
+// RunEventPruning periodically forgets old event ids until ctx is cancelled
+func (s *NotificationService) RunEventPruning(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := s.events.PruneEvents(ctx, s.clock.Now().Add(-processedEventRetention))
+			if err != nil {
+				log.Printf("Failed to prune processed events: %v", err)
+				continue
+			}
+			if pruned > 0 {
+				log.Printf("Pruned %d processed events", pruned)
+			}
+		}
+	}
+}
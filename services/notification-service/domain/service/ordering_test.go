@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"restaurant-system/shared/clock"
+	sharedevents "restaurant-system/shared/events"
+)
+
+// memoryEvents keeps the same state as notification_processed_events and
+// notification_order_state
+type memoryEvents struct {
+	ports.EventStateRepository
+	processed map[string]bool
+	last      map[string]string
+	nextID    int
+	fail      bool
+}
+
+func (m *memoryEvents) Accept(ctx context.Context, eventID, orderNumber, status string, predecessors []string, deliveries []*models.Delivery) (models.EventDecision, error) {
+	if m.fail {
+		return models.EventAccepted, errors.New("database is down")
+	}
+	if eventID != "" && m.processed[eventID] {
+		return models.EventDuplicate, nil
+	}
+	if predecessors != nil {
+		if last, ok := m.last[orderNumber]; ok && !slices.Contains(predecessors, last) {
+			return models.EventOutOfOrder, nil
+		}
+		m.last[orderNumber] = status
+	}
+	if eventID != "" {
+		m.processed[eventID] = true
+	}
+	for _, delivery := range deliveries {
+		m.nextID++
+		delivery.ID = m.nextID
+	}
+	return models.EventAccepted, nil
+}
+
+type memoryDeliveries struct {
+	ports.DeliveryRepository
+	updated []models.Delivery
+	// due is what ClaimDue hands to the retry loop
+	due []models.Delivery
+}
+
+func (m *memoryDeliveries) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Delivery, error) {
+	return m.due, nil
+}
+
+func (m *memoryDeliveries) Update(ctx context.Context, delivery *models.Delivery) error {
+	m.updated = append(m.updated, *delivery)
+	return nil
+}
+
+type noRecipients struct{}
+
+func (noRecipients) GetRecipient(ctx context.Context, orderNumber string) (*models.Recipient, error) {
+	return nil, nil
+}
+
+// recordingNotifier is an operator channel that remembers what it sent
+type recordingNotifier struct {
+	sent []string
+}
+
+func (n *recordingNotifier) Channel() string         { return "log" }
+func (n *recordingNotifier) CustomerChannel() string { return "" }
+func (n *recordingNotifier) Send(ctx context.Context, notification models.Notification) error {
+	n.sent = append(n.sent, notification.NewStatus)
+	return nil
+}
+
+func newTestService(events ports.EventStateRepository, deliveries ports.DeliveryRepository, notifier ports.Notifier) *NotificationService {
+	clk := clock.NewManual(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	return NewNotificationService([]ports.Notifier{notifier}, noRecipients{}, deliveries, events, nil, time.UTC, clk)
+}
+
+func TestHandleStatusUpdateOrdering(t *testing.T) {
+	events := &memoryEvents{processed: map[string]bool{}, last: map[string]string{}}
+	deliveries := &memoryDeliveries{}
+	notifier := &recordingNotifier{}
+	s := newTestService(events, deliveries, notifier)
+
+	updates := []models.StatusUpdateMessage{
+		{EventID: "e1", OrderNumber: "ORD_1", NewStatus: "received"},
+		{EventID: "e2", OrderNumber: "ORD_1", OldStatus: "received", NewStatus: "cooking"},
+		{EventID: "e2", OrderNumber: "ORD_1", OldStatus: "received", NewStatus: "cooking"}, // redelivered
+		{EventID: "e3", OrderNumber: "ORD_1", OldStatus: "received", NewStatus: "cooking"}, // kitchen retry
+		{EventID: "e5", OrderNumber: "ORD_2", NewStatus: "ready"},                          // first seen late
+		{EventID: "e4", OrderNumber: "ORD_1", OldStatus: "cooking", NewStatus: "ready"},
+		{EventID: "e6", OrderNumber: "ORD_1", OldStatus: "received", NewStatus: "cooking"}, // late
+		{EventID: "e7", OrderNumber: "ORD_1", OldStatus: "ready", NewStatus: "completed"},
+	}
+	for _, update := range updates {
+		if err := s.HandleStatusUpdate(update); err != nil {
+			t.Fatalf("HandleStatusUpdate(%s): %v", update.EventID, err)
+		}
+	}
+
+	want := []string{"received", "cooking", "ready", "ready", "completed"}
+	if !slices.Equal(notifier.sent, want) {
+		t.Errorf("sent %v, want %v", notifier.sent, want)
+	}
+	if len(deliveries.updated) != len(want) {
+		t.Fatalf("updated %d deliveries, want %d", len(deliveries.updated), len(want))
+	}
+	for _, delivery := range deliveries.updated {
+		if delivery.ID == 0 || delivery.Status != models.DeliverySent || delivery.Attempts != 1 {
+			t.Errorf("delivery %+v, want a recorded sent delivery", delivery)
+		}
+	}
+}
+
+func TestHandleStatusUpdateWithoutStateStore(t *testing.T) {
+	events := &memoryEvents{fail: true}
+	deliveries := &memoryDeliveries{}
+	notifier := &recordingNotifier{}
+	s := newTestService(events, deliveries, notifier)
+
+	err := s.HandleStatusUpdate(models.StatusUpdateMessage{EventID: "e1", OrderNumber: "ORD_1", NewStatus: "received"})
+	if err == nil {
+		t.Fatal("HandleStatusUpdate should fail so the event is redelivered")
+	}
+	// nothing goes out without a record of the event and its deliveries
+	if len(notifier.sent) != 0 || len(deliveries.updated) != 0 {
+		t.Errorf("sent %v, updated %d deliveries; want nothing", notifier.sent, len(deliveries.updated))
+	}
+}
+
+// The kitchen publishes "cooking" again in two ways: the same order_status_log row
+// republished (same event id), and a redelivered order cooked again, which writes
+// a new row and so gets a new id. Only the first "cooking" may be notified.
+func TestRepublishedCookingEvent(t *testing.T) {
+	events := &memoryEvents{processed: map[string]bool{}, last: map[string]string{}}
+	notifier := &recordingNotifier{}
+	s := newTestService(events, &memoryDeliveries{}, notifier)
+
+	update := func(status string, statusLogID int64) models.StatusUpdateMessage {
+		return models.StatusUpdateMessage{
+			EventID:     sharedevents.TransitionEventID("ORD_1", status, statusLogID),
+			OrderNumber: "ORD_1",
+			NewStatus:   status,
+		}
+	}
+
+	steps := []struct {
+		name   string
+		update models.StatusUpdateMessage
+		sent   []string
+	}{
+		{"received", update("received", 0), []string{"received"}},
+		{"cooking", update("cooking", 2), []string{"received", "cooking"}},
+		{"same row republished", update("cooking", 2), []string{"received", "cooking"}},
+		{"cooked again after redelivery", update("cooking", 3), []string{"received", "cooking"}},
+		{"ready", update("ready", 4), []string{"received", "cooking", "ready"}},
+		{"cooking republished after ready", update("cooking", 3), []string{"received", "cooking", "ready"}},
+	}
+	for _, step := range steps {
+		if err := s.HandleStatusUpdate(step.update); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if !slices.Equal(notifier.sent, step.sent) {
+			t.Errorf("after %s sent %v, want %v", step.name, notifier.sent, step.sent)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"restaurant-system/services/notification-service/domain/models"
+	"restaurant-system/services/notification-service/domain/ports"
+	"time"
+)
+
+const (
+	// webhooks are sent by the polling loop; new events wake it up early
+	webhookPollInterval = 2 * time.Second
+	webhookBatchSize    = 50
+)
+
+// WebhookService forwards fanout events to the webhook subscriptions registered in
+// order-service, retrying failed sends with the same backoff as notifications
+type WebhookService struct {
+	repo   ports.WebhookRepository
+	sender ports.WebhookSender
+	clock  ports.Clock
+	wake   chan struct{}
+}
+
+func NewWebhookService(repo ports.WebhookRepository, sender ports.WebhookSender, clock ports.Clock) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+		clock:  clock,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent queues the event for every subscription that wants it. An error
+// means nothing was queued and the event should be redelivered.
+func (s *WebhookService) HandleEvent(event models.WebhookEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	queued, err := s.repo.Enqueue(ctx, event)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to queue webhooks for %s event %s: %w", event.EventType, event.EventID, err)
+	}
+	if queued == 0 {
+		return nil
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run sends queued and due webhooks until ctx is cancelled
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		s.sendDue(ctx)
+	}
+}
+
+func (s *WebhookService) sendDue(ctx context.Context) {
+	deliveries, err := s.repo.ClaimDue(ctx, webhookBatchSize, retryLease)
+	if err != nil {
+		log.Printf("Failed to load due webhooks: %v", err)
+		return
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		s.attempt(delivery)
+		if err := s.repo.Update(ctx, delivery); err != nil {
+			log.Printf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+}
+
+// attempt sends the delivery once and moves it to its next state
+func (s *WebhookService) attempt(delivery *models.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	statusCode, err := s.sender.Send(ctx, *delivery)
+	cancel()
+
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	if statusCode != 0 {
+		delivery.ResponseStatus = &statusCode
+	}
+	now := s.clock.Now()
+
+	if err != nil {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= maxDeliveryAttempts {
+			log.Printf("Giving up on webhook %d for %s event %s after %d attempts: %v",
+				delivery.SubscriptionID, delivery.EventType, delivery.EventID, delivery.Attempts, err)
+			delivery.Status = models.DeliveryFailed
+			delivery.NextAttemptAt = nil
+			return
+		}
+		next := now.Add(retryDelay(delivery.Attempts))
+		log.Printf("Failed to send webhook %d for %s event %s (attempt %d, retry at %s): %v",
+			delivery.SubscriptionID, delivery.EventType, delivery.EventID, delivery.Attempts, next.Format(time.RFC3339), err)
+		delivery.Status = models.DeliveryPending
+		delivery.NextAttemptAt = &next
+		return
+	}
+
+	log.Printf("Sent webhook %d for %s event %s", delivery.SubscriptionID, delivery.EventType, delivery.EventID)
+	delivery.Status = models.DeliverySent
+	delivery.LastError = ""
+	delivery.NextAttemptAt = nil
+	delivery.DeliveredAt = &now
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/utils/logger"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresInventoryRepository struct {
+	DB     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresInventoryRepository(db *pgxpool.Pool, serviceName string) *PostgresInventoryRepository {
+	return &PostgresInventoryRepository{
+		DB:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+func (r *PostgresInventoryRepository) ListIngredients(ctx context.Context) ([]models.Ingredient, error) {
+	query := `
+		SELECT id, name, unit, stock_quantity, reserved_quantity,
+		       stock_quantity - reserved_quantity, low_stock_threshold, updated_at
+		FROM ingredients
+		ORDER BY name
+	`
+
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ingredients: %w", err)
+	}
+	defer rows.Close()
+
+	var ingredients []models.Ingredient
+	for rows.Next() {
+		var ingredient models.Ingredient
+		if err := scanIngredient(rows, &ingredient); err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient: %w", err)
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ingredients: %w", err)
+	}
+
+	return ingredients, nil
+}
+
+func (r *PostgresInventoryRepository) Restock(ctx context.Context, name string, quantity float64) (*models.Ingredient, error) {
+	query := `
+		UPDATE ingredients
+		SET stock_quantity = stock_quantity + $2, updated_at = NOW()
+		WHERE name = $1
+		RETURNING id, name, unit, stock_quantity, reserved_quantity,
+		          stock_quantity - reserved_quantity, low_stock_threshold, updated_at
+	`
+
+	var ingredient models.Ingredient
+	if err := scanIngredient(r.DB.QueryRow(ctx, query, name, quantity), &ingredient); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrIngredientNotFound
+		}
+		return nil, fmt.Errorf("failed to restock ingredient: %w", err)
+	}
+
+	r.Logger.Info("ingredient_restocked", fmt.Sprintf("Ingredient %s restocked by %.3f", name, quantity), "")
+	return &ingredient, nil
+}
+
+func scanIngredient(row pgx.Row, ingredient *models.Ingredient) error {
+	return row.Scan(
+		&ingredient.ID,
+		&ingredient.Name,
+		&ingredient.Unit,
+		&ingredient.StockQuantity,
+		&ingredient.ReservedQuantity,
+		&ingredient.Available,
+		&ingredient.LowStockThreshold,
+		&ingredient.UpdatedAt,
+	)
+}
+
+// reserveIngredients reserves recipe ingredients for the order inside tx.
+// Items without a recipe are not inventory tracked and are skipped.
+func reserveIngredients(ctx context.Context, tx pgx.Tx, orderID int, items []models.OrderItem) error {
+	quantities := make(map[string]int)
+	var names []string
+	for _, item := range items {
+		key := strings.ToLower(item.Name)
+		if _, ok := quantities[key]; !ok {
+			names = append(names, key)
+		}
+		quantities[key] += item.Quantity
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT lower(menu_item), menu_item, ingredient_id, quantity
+		FROM recipes
+		WHERE lower(menu_item) = ANY($1)
+	`, names)
+	if err != nil {
+		return fmt.Errorf("failed to query recipes: %w", err)
+	}
+
+	required := make(map[int]float64)
+	usedBy := make(map[int][]string)
+	for rows.Next() {
+		var key, menuItem string
+		var ingredientID int
+		var perItem float64
+		if err := rows.Scan(&key, &menuItem, &ingredientID, &perItem); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan recipe: %w", err)
+		}
+		required[ingredientID] += perItem * float64(quantities[key])
+		usedBy[ingredientID] = append(usedBy[ingredientID], menuItem)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating recipes: %w", err)
+	}
+
+	if len(required) == 0 {
+		return nil
+	}
+
+	ingredientIDs := make([]int, 0, len(required))
+	for id := range required {
+		ingredientIDs = append(ingredientIDs, id)
+	}
+	sort.Ints(ingredientIDs)
+
+	// Lock in id order so concurrent orders cannot deadlock
+	rows, err = tx.Query(ctx, `
+		SELECT id, name, stock_quantity - reserved_quantity
+		FROM ingredients
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`, ingredientIDs)
+	if err != nil {
+		return fmt.Errorf("failed to lock ingredients: %w", err)
+	}
+
+	var shortages []models.StockShortage
+	for rows.Next() {
+		var id int
+		var name string
+		var available float64
+		if err := rows.Scan(&id, &name, &available); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan ingredient: %w", err)
+		}
+		if available < required[id] {
+			shortages = append(shortages, models.StockShortage{
+				Ingredient: name,
+				Required:   required[id],
+				Available:  available,
+				MenuItems:  usedBy[id],
+			})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating ingredients: %w", err)
+	}
+
+	if len(shortages) > 0 {
+		return &models.OutOfStockError{Shortages: shortages}
+	}
+
+	for _, id := range ingredientIDs {
+		if _, err := tx.Exec(ctx, `
+			UPDATE ingredients
+			SET reserved_quantity = reserved_quantity + $2, updated_at = NOW()
+			WHERE id = $1
+		`, id, required[id]); err != nil {
+			return fmt.Errorf("failed to reserve ingredient: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO inventory_reservations (order_id, ingredient_id, quantity)
+			VALUES ($1, $2, $3)
+		`, orderID, id, required[id]); err != nil {
+			return fmt.Errorf("failed to save reservation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseReservations returns the order's still reserved ingredients to stock inside tx
+func releaseReservations(ctx context.Context, tx pgx.Tx, orderID int) error {
+	_, err := tx.Exec(ctx, `
+		WITH released AS (
+			UPDATE inventory_reservations
+			SET status = 'released', updated_at = NOW()
+			WHERE order_id = $1 AND status = 'reserved'
+			RETURNING ingredient_id, quantity
+		)
+		UPDATE ingredients i
+		SET reserved_quantity = i.reserved_quantity - r.quantity, updated_at = NOW()
+		FROM (
+			SELECT ingredient_id, sum(quantity) AS quantity
+			FROM released
+			GROUP BY ingredient_id
+		) r
+		WHERE i.id = r.ingredient_id
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to release reservations: %w", err)
+	}
+	return nil
+}
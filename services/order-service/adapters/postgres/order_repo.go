@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"fmt"
 	"restaurant-system/services/order-service/domain/models"
@@ -33,8 +34,8 @@ func (r *PostgresOrderRepository) SaveOrderWithItems(ctx context.Context, order
 
 	// Save order
 	orderQuery := `
-		INSERT INTO orders (number, customer_name, type, table_number, delivery_address, total_amount, priority, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO orders (number, tracking_token, customer_name, type, table_number, delivery_address, total_amount, priority, status, customer_phone, customer_email)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -45,6 +46,7 @@ func (r *PostgresOrderRepository) SaveOrderWithItems(ctx context.Context, order
 
 	err = tx.QueryRow(ctx, orderQuery,
 		order.OrderNumber,
+		order.TrackingToken,
 		order.CustomerName,
 		order.OrderType,
 		tableNumber,
@@ -52,11 +54,24 @@ func (r *PostgresOrderRepository) SaveOrderWithItems(ctx context.Context, order
 		order.TotalAmount,
 		order.Priority,
 		order.Status,
+		order.CustomerPhone,
+		order.CustomerEmail,
 	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to save order: %w", err)
 	}
 
+	// Save notification preferences, read by notification-service
+	if order.Notifications != nil {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO notification_preferences (order_id, channels, statuses, locale)
+			VALUES ($1, $2, $3, $4)
+		`, order.ID, order.Notifications.Channels, order.Notifications.Statuses, order.Notifications.Locale)
+		if err != nil {
+			return fmt.Errorf("failed to save notification preferences: %w", err)
+		}
+	}
+
 	// Save order items
 	itemQuery := `
 		INSERT INTO order_items (order_id, name, quantity, price)
@@ -77,6 +92,11 @@ func (r *PostgresOrderRepository) SaveOrderWithItems(ctx context.Context, order
 		}
 	}
 
+	// Reserve ingredients; rolls back the whole order when out of stock
+	if err := reserveIngredients(ctx, tx, order.ID, items); err != nil {
+		return err
+	}
+
 	// Save status log
 	statusLogQuery := `
 		INSERT INTO order_status_log (order_id, status, changed_by, changed_at)
@@ -101,6 +121,18 @@ func (r *PostgresOrderRepository) SaveOrderWithItems(ctx context.Context, order
 		return fmt.Errorf("failed to save status log: %w", err)
 	}
 
+	// The received announcement commits with the order, so it cannot be lost
+	err = insertStatusEvent(ctx, tx, models.StatusEvent{
+		OrderNumber:   order.OrderNumber,
+		OrderType:     order.OrderType,
+		NewStatus:     order.Status,
+		CorrelationID: order.CorrelationID,
+		ChangedAt:     order.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -140,7 +172,7 @@ func (r *PostgresOrderRepository) GetOrderByNumber(ctx context.Context, orderNum
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("order not found")
+			return nil, models.ErrOrderNotFound
 		}
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
@@ -212,3 +244,76 @@ func (r *PostgresOrderRepository) UpdateOrderStatus(ctx context.Context, orderID
 
 	return nil
 }
+
+func (r *PostgresOrderRepository) CancelOrder(ctx context.Context, orderNumber, trackingToken, changedBy string) (*models.Order, error) {
+	tx, err := r.DB.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var order models.Order
+	var storedToken *string
+	err = tx.QueryRow(ctx, `
+		SELECT id, number, type, status, total_amount, tracking_token
+		FROM orders
+		WHERE number = $1
+		FOR UPDATE
+	`, orderNumber).Scan(&order.ID, &order.OrderNumber, &order.OrderType, &order.Status, &order.TotalAmount, &storedToken)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	// A wrong token looks like an unknown order, so numbers cannot be probed
+	if trackingToken != "" && (storedToken == nil || subtle.ConstantTimeCompare([]byte(*storedToken), []byte(trackingToken)) != 1) {
+		return nil, models.ErrOrderNotFound
+	}
+
+	// Only orders the kitchen has not picked up yet can be cancelled
+	if order.Status != "received" {
+		return nil, fmt.Errorf("%w: order is %s", models.ErrOrderNotCancellable, order.Status)
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE orders
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE id = $1
+		RETURNING status, updated_at
+	`, order.ID).Scan(&order.Status, &order.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO order_status_log (order_id, status, changed_by, changed_at, notes)
+		VALUES ($1, $2, $3, NOW(), $4)
+	`, order.ID, order.Status, changedBy, "order cancelled, ingredients released")
+	if err != nil {
+		return nil, fmt.Errorf("failed to save status log: %w", err)
+	}
+
+	if err := releaseReservations(ctx, tx, order.ID); err != nil {
+		return nil, err
+	}
+
+	err = insertStatusEvent(ctx, tx, models.StatusEvent{
+		OrderNumber: order.OrderNumber,
+		OrderType:   order.OrderType,
+		OldStatus:   "received",
+		NewStatus:   order.Status,
+		ChangedAt:   order.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.Logger.Info("order_cancelled", fmt.Sprintf("Order %s cancelled", order.OrderNumber), "")
+	return &order, nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/utils/logger"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresStatusOutboxRepository struct {
+	DB     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresStatusOutboxRepository(db *pgxpool.Pool, serviceName string) *PostgresStatusOutboxRepository {
+	return &PostgresStatusOutboxRepository{
+		DB:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+// insertStatusEvent queues a status event inside the transaction that made the change
+func insertStatusEvent(ctx context.Context, tx pgx.Tx, event models.StatusEvent) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO status_outbox (order_number, order_type, old_status, new_status, correlation_id, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, event.OrderNumber, event.OrderType, event.OldStatus, event.NewStatus, event.CorrelationID, event.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to queue status event: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue bumps each claimed event's attempts and pushes its next attempt back
+// 1s, 2s, 4s... up to 5 minutes; a published event is then marked and never claimed again.
+// An event waits while an earlier event of the same order is unpublished.
+func (r *PostgresStatusOutboxRepository) ClaimDue(ctx context.Context, limit int) ([]models.StatusEvent, error) {
+	rows, err := r.DB.Query(ctx, `
+		UPDATE status_outbox
+		SET attempts = attempts + 1,
+		    next_attempt_at = NOW() + least(interval '1 second' * power(2, attempts), interval '5 minutes')
+		WHERE id IN (
+			SELECT o.id
+			FROM status_outbox o
+			WHERE o.published_at IS NULL AND o.next_attempt_at <= NOW()
+			  -- an order's events go out in the order they were made
+			  AND NOT EXISTS (
+				SELECT 1 FROM status_outbox e
+				WHERE e.order_number = o.order_number AND e.published_at IS NULL AND e.id < o.id
+			  )
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, order_number, order_type, old_status, new_status, correlation_id, changed_at, attempts
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim status events: %w", err)
+	}
+	defer rows.Close()
+
+	var statusEvents []models.StatusEvent
+	for rows.Next() {
+		var event models.StatusEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.OrderNumber,
+			&event.OrderType,
+			&event.OldStatus,
+			&event.NewStatus,
+			&event.CorrelationID,
+			&event.ChangedAt,
+			&event.Attempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status event: %w", err)
+		}
+		statusEvents = append(statusEvents, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status events: %w", err)
+	}
+
+	// UPDATE ... RETURNING does not keep the subquery's order
+	slices.SortFunc(statusEvents, func(a, b models.StatusEvent) int { return a.ID - b.ID })
+	return statusEvents, nil
+}
+
+func (r *PostgresStatusOutboxRepository) MarkPublished(ctx context.Context, id int) error {
+	_, err := r.DB.Exec(ctx, `
+		UPDATE status_outbox
+		SET published_at = NOW()
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark status event %d published: %w", id, err)
+	}
+	return nil
+}
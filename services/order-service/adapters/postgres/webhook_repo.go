@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/utils/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWebhookRepository struct {
+	DB     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresWebhookRepository(db *pgxpool.Pool, serviceName string) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		DB:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+func (r *PostgresWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (url, event_types, secret, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.DB.QueryRow(ctx, query,
+		subscription.URL,
+		subscription.EventTypes,
+		subscription.Secret,
+		subscription.Active,
+	).Scan(&subscription.ID, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWebhookRepository) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, event_types, active, created_at, updated_at
+		FROM webhook_subscriptions
+		ORDER BY id
+	`
+
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []models.WebhookSubscription{}
+	for rows.Next() {
+		var subscription models.WebhookSubscription
+		if err := scanWebhookSubscription(rows, &subscription); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *PostgresWebhookRepository) GetSubscription(ctx context.Context, id int) (*models.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, event_types, active, created_at, updated_at
+		FROM webhook_subscriptions
+		WHERE id = $1
+	`
+
+	var subscription models.WebhookSubscription
+	err := scanWebhookSubscription(r.DB.QueryRow(ctx, query, id), &subscription)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+func (r *PostgresWebhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $2,
+			event_types = $3,
+			secret = coalesce(nullif($4, ''), secret),
+			active = $5,
+			updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`
+
+	err := r.DB.QueryRow(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		subscription.EventTypes,
+		subscription.Secret,
+		subscription.Active,
+	).Scan(&subscription.CreatedAt, &subscription.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrWebhookNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWebhookRepository) DeleteSubscription(ctx context.Context, id int) error {
+	tag, err := r.DB.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *PostgresWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT id, event_id, event_type, status, attempts, response_status,
+		       last_error, next_attempt_at, delivered_at, created_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.DB.Query(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhookSubscription(row pgx.Row, subscription *models.WebhookSubscription) error {
+	return row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.EventTypes,
+		&subscription.Active,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+}
@@ -0,0 +1,120 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/utils/logger"
+	"restaurant-system/shared/events"
+	"sync"
+)
+
+// WorkerControlClient sends control commands to kitchen workers and waits for their acks
+type WorkerControlClient struct {
+	client     *Client
+	logger     *logger.Logger
+	replyQueue string
+
+	mu      sync.Mutex
+	pending map[string]chan models.WorkerCommandAck
+}
+
+func NewWorkerControlClient(client *Client, serviceName string) (*WorkerControlClient, error) {
+	queue, err := client.DeclareReplyQueue()
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := client.ConsumeAutoAck(queue.Name, "worker-control-replies")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &WorkerControlClient{
+		client:     client,
+		logger:     logger.New(serviceName),
+		replyQueue: queue.Name,
+		pending:    make(map[string]chan models.WorkerCommandAck),
+	}
+
+	go func() {
+		for delivery := range replies {
+			var ack events.WorkerCommandAck
+			if err := json.Unmarshal(delivery.Body, &ack); err != nil {
+				c.logger.Error("control_ack_decode_failed", "Failed to decode worker ack", delivery.CorrelationId, err)
+				continue
+			}
+			c.resolve(delivery.CorrelationId, models.WorkerCommandAck{
+				WorkerName: ack.WorkerName,
+				Command:    ack.Command,
+				Accepted:   ack.Accepted,
+				State:      ack.State,
+				OrderTypes: ack.OrderTypes,
+				InFlight:   ack.InFlight,
+				Buffered:   ack.Buffered,
+				Error:      ack.Error,
+				Timestamp:  ack.Timestamp,
+			})
+		}
+	}()
+
+	return c, nil
+}
+
+func (c *WorkerControlClient) SendWorkerCommand(ctx context.Context, workerName string, command *models.WorkerCommand) (*models.WorkerCommandAck, error) {
+	correlationID, err := events.NewEventID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate correlation id: %w", err)
+	}
+
+	envelope, err := events.NewEnvelope(events.TypeWorkerCommand, events.WorkerCommandVersion, correlationID, command.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	messageBytes, err := json.Marshal(events.WorkerCommand{
+		Envelope:   envelope,
+		Command:    command.Command,
+		OrderTypes: command.OrderTypes,
+		IssuedAt:   command.IssuedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal worker command: %w", err)
+	}
+
+	replyCh := make(chan models.WorkerCommandAck, 1)
+	c.mu.Lock()
+	c.pending[correlationID] = replyCh
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, correlationID)
+		c.mu.Unlock()
+	}()
+
+	routingKey := fmt.Sprintf("control.%s", workerName)
+	if err := c.client.PublishRequest(ctx, "orders_topic", routingKey, correlationID, c.replyQueue, messageBytes); err != nil {
+		c.logger.Error("control_publish_failed", "Failed to publish worker command", correlationID, err)
+		return nil, fmt.Errorf("failed to publish worker command: %w", err)
+	}
+
+	c.logger.Debug("control_command_sent", fmt.Sprintf("Command %s sent to worker %s", command.Command, workerName), correlationID)
+
+	select {
+	case ack := <-replyCh:
+		return &ack, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("worker %s did not acknowledge command: %w", workerName, ctx.Err())
+	}
+}
+
+func (c *WorkerControlClient) resolve(correlationID string, ack models.WorkerCommandAck) {
+	c.mu.Lock()
+	replyCh, ok := c.pending[correlationID]
+	c.mu.Unlock()
+	if !ok {
+		// ответ пришёл после таймаута
+		return
+	}
+	replyCh <- ack
+}
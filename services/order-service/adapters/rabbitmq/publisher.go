@@ -4,25 +4,59 @@ import (
 	"encoding/json"
 	"fmt"
 	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/domain/ports"
 	"restaurant-system/services/order-service/utils/logger"
+	"restaurant-system/shared/events"
+	"time"
 )
 
 type RabbitMQPublisher struct {
 	client *Client
+	clock  ports.Clock
 	logger *logger.Logger
 }
 
-func NewRabbitMQPublisher(client *Client, serviceName string) *RabbitMQPublisher {
+func NewRabbitMQPublisher(client *Client, clock ports.Clock, serviceName string) *RabbitMQPublisher {
 	return &RabbitMQPublisher{
 		client: client,
+		clock:  clock,
 		logger: logger.New(serviceName),
 	}
 }
 
+// PublishOrder sends the order to the kitchen. Its received status event is
+// queued with the order in status_outbox and published by the status relay.
 func (p *RabbitMQPublisher) PublishOrder(order *models.OrderMessage) error {
-	// Prepare message according to TZ format
+	// Prepare message according to the shared event contract
+	envelope, err := events.NewEnvelope(events.TypeOrderCreated, events.OrderCreatedVersion, "", p.clock.Now())
+	if err != nil {
+		return err
+	}
+	// the order's first event starts the correlation chain the kitchen continues
+	envelope.CorrelationID = order.CorrelationID
+	if envelope.CorrelationID == "" {
+		envelope.CorrelationID = envelope.EventID
+	}
+
+	event := events.OrderCreated{
+		Envelope:        envelope,
+		OrderNumber:     order.OrderNumber,
+		CustomerName:    order.CustomerName,
+		OrderType:       order.OrderType,
+		TableNumber:     order.TableNumber,
+		DeliveryAddress: order.DeliveryAddress,
+		TotalAmount:     order.TotalAmount,
+		Priority:        order.Priority,
+	}
+	for _, item := range order.Items {
+		event.Items = append(event.Items, events.OrderItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
 
-	messageBytes, err := json.Marshal(order)
+	messageBytes, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal order: %w", err)
 	}
@@ -30,13 +64,52 @@ func (p *RabbitMQPublisher) PublishOrder(order *models.OrderMessage) error {
 	// Generate routing key according to TZ
 	routingKey := fmt.Sprintf("kitchen.%s.%d", order.OrderType, order.Priority)
 
-	// Publish with persistent delivery mode
-	err = p.client.PublishWithPersistentDelivery("orders_topic", routingKey, messageBytes)
+	// Publish with persistent delivery mode; the kitchen queues hand out higher priorities first
+	err = p.client.PublishWithPersistentDelivery("orders_topic", routingKey, messageBytes, uint8(order.Priority))
 	if err != nil {
 		p.logger.Error("rabbitmq_publish_failed", "Failed to publish order to RabbitMQ", order.OrderNumber, err)
 		return fmt.Errorf("failed to publish order: %w", err)
 	}
 
 	p.logger.Debug("order_published", fmt.Sprintf("Order %s published to RabbitMQ with routing key %s", order.OrderNumber, routingKey), order.OrderNumber)
+
+	return nil
+}
+
+// PublishStatusChanged announces a status event from status_outbox
+func (p *RabbitMQPublisher) PublishStatusChanged(event models.StatusEvent) error {
+	return p.publishStatus(event.OrderNumber, event.OrderType, event.OldStatus, event.NewStatus, event.CorrelationID, event.ChangedAt)
+}
+
+func (p *RabbitMQPublisher) publishStatus(orderNumber, orderType, oldStatus, newStatus, correlationID string, changedAt time.Time) error {
+	envelope, err := events.NewEnvelope(events.TypeOrderStatusChanged, events.OrderStatusChangedVersion, correlationID, changedAt)
+	if err != nil {
+		return err
+	}
+	// received and cancelled happen at most once per order, so the number and
+	// status alone identify the transition and a republish repeats the id
+	envelope.EventID = events.TransitionEventID(orderNumber, newStatus, 0)
+
+	messageBytes, err := json.Marshal(events.OrderStatusChanged{
+		Envelope:    envelope,
+		OrderNumber: orderNumber,
+		OrderType:   orderType,
+		OldStatus:   oldStatus,
+		NewStatus:   newStatus,
+		ChangedBy:   "order-service",
+		Timestamp:   changedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal status event: %w", err)
+	}
+
+	// Publish with persistent delivery mode, like the order itself
+	err = p.client.PublishWithPersistentDelivery("notifications_fanout", "", messageBytes, 0)
+	if err != nil {
+		p.logger.Error("rabbitmq_publish_failed", fmt.Sprintf("Failed to publish %s status event to RabbitMQ", newStatus), orderNumber, err)
+		return fmt.Errorf("failed to publish status event: %w", err)
+	}
+
+	p.logger.Debug("status_published", fmt.Sprintf("Order %s announced as %s on notifications_fanout", orderNumber, newStatus), orderNumber)
 	return nil
 }
@@ -76,6 +76,22 @@ func (c *Client) DeclareQueue(queueName string) (amqp.Queue, error) {
 	return queue, nil
 }
 
+// DeclareReplyQueue объявляет эксклюзивную очередь с именем от сервера для ответов
+func (c *Client) DeclareReplyQueue() (amqp.Queue, error) {
+	queue, err := c.channel.QueueDeclare(
+		"",    // server-named
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // args
+	)
+	if err != nil {
+		return amqp.Queue{}, fmt.Errorf("failed to declare reply queue: %w", err)
+	}
+	return queue, nil
+}
+
 func (c *Client) BindQueue(queueName, exchange, routingKey string) error {
 	err := c.channel.QueueBind(
 		queueName,
@@ -106,7 +122,9 @@ func (c *Client) Publish(exchange, routingKey string, message []byte) error {
 		})
 }
 
-func (c *Client) PublishWithPersistentDelivery(exchange, routingKey string, message []byte) error {
+// PublishWithPersistentDelivery publishes a persistent message; priority matters only
+// for queues declared with x-max-priority, such as the kitchen's order queues
+func (c *Client) PublishWithPersistentDelivery(exchange, routingKey string, message []byte, priority uint8) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -119,7 +137,22 @@ func (c *Client) PublishWithPersistentDelivery(exchange, routingKey string, mess
 			ContentType:  "application/json",
 			Body:         message,
 			DeliveryMode: amqp.Persistent, // Persistent delivery mode
-			Priority:     0,               // You can set priority here if needed
+			Priority:     priority,
+		})
+}
+
+// PublishRequest публикует сообщение, на которое ожидается ответ в replyTo
+func (c *Client) PublishRequest(ctx context.Context, exchange, routingKey, correlationID, replyTo string, message []byte) error {
+	return c.channel.PublishWithContext(ctx,
+		exchange,   // exchange
+		routingKey, // routing key
+		false,      // mandatory
+		false,      // immediate
+		amqp.Publishing{
+			ContentType:   "application/json",
+			CorrelationId: correlationID,
+			ReplyTo:       replyTo,
+			Body:          message,
 		})
 }
 
@@ -139,6 +172,22 @@ func (c *Client) Consume(queueName, consumer string) (<-chan amqp.Delivery, erro
 	return msgs, nil
 }
 
+func (c *Client) ConsumeAutoAck(queueName, consumer string) (<-chan amqp.Delivery, error) {
+	msgs, err := c.channel.Consume(
+		queueName,
+		consumer, // consumer
+		true,     // auto-ack
+		true,     // exclusive
+		false,    // no-local
+		false,    // no-wait
+		nil,      // args
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume from queue %s: %w", queueName, err)
+	}
+	return msgs, nil
+}
+
 func (c *Client) Close() {
 	if c.channel != nil {
 		c.channel.Close()
@@ -0,0 +1,100 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"restaurant-system/services/order-service/domain/models"
+	"strings"
+	"time"
+)
+
+// Worker acks are expected well within this window
+const workerCommandTimeout = 5 * time.Second
+
+func (h *WebHandler) HandleWorkerCommand(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	workerName := r.PathValue("worker_name")
+	h.Logger.Info("request_received", fmt.Sprintf("Received command for worker %s", workerName), requestID)
+
+	var request models.WorkerCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("invalid_json", "Invalid JSON format", requestID, err)
+		sendJSONError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), workerCommandTimeout)
+	defer cancel()
+
+	ack, err := h.AdminService.SendWorkerCommand(ctx, workerName, request)
+	if err != nil {
+		h.Logger.Error("worker_command_failed", "Failed to deliver worker command", requestID, err)
+
+		switch {
+		case strings.Contains(err.Error(), "validation"):
+			sendJSONError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, context.DeadlineExceeded):
+			sendJSONError(w, http.StatusGatewayTimeout, fmt.Sprintf("worker %s did not acknowledge the command", workerName))
+		default:
+			sendJSONError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// воркер ответил, но команду не применил
+	status := http.StatusOK
+	if !ack.Accepted {
+		status = http.StatusConflict
+	}
+
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(ack); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+type adminKey struct{}
+
+// allowAdmin marks requests carrying a valid X-Admin-Token, for routes that
+// staff and customers share; the handler checks isAdmin
+func allowAdmin(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if validAdminToken(token, r) {
+			r = r.WithContext(context.WithValue(r.Context(), adminKey{}, true))
+		}
+		next(w, r)
+	}
+}
+
+func isAdmin(r *http.Request) bool {
+	admin, _ := r.Context().Value(adminKey{}).(bool)
+	return admin
+}
+
+func validAdminToken(token string, r *http.Request) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) == 1
+}
+
+// requireAdmin checks the X-Admin-Token header; without a configured token
+// every admin request is refused
+func requireAdmin(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			w.Header().Set("Content-Type", "application/json")
+			sendJSONError(w, http.StatusServiceUnavailable, "Admin API is disabled: ADMIN_TOKEN is not set")
+			return
+		}
+		if !validAdminToken(token, r) {
+			w.Header().Set("Content-Type", "application/json")
+			sendJSONError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
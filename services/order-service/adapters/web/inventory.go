@@ -0,0 +1,62 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"restaurant-system/services/order-service/domain/models"
+	"strings"
+)
+
+func (h *WebHandler) HandleListInventory(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	ingredients, err := h.InventoryService.ListIngredients(r.Context())
+	if err != nil {
+		h.Logger.Error("inventory_list_failed", "Failed to list ingredients", requestID, err)
+		sendJSONError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ingredients); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func (h *WebHandler) HandleRestock(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	name := r.PathValue("ingredient")
+	h.Logger.Info("request_received", fmt.Sprintf("Received restock request for %s", name), requestID)
+
+	var request models.RestockRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("invalid_json", "Invalid JSON format", requestID, err)
+		sendJSONError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	ingredient, err := h.InventoryService.Restock(r.Context(), name, request)
+	if err != nil {
+		h.Logger.Error("restock_failed", "Failed to restock ingredient", requestID, err)
+
+		switch {
+		case strings.Contains(err.Error(), "validation"):
+			sendJSONError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, models.ErrIngredientNotFound):
+			sendJSONError(w, http.StatusNotFound, "Ingredient not found")
+		default:
+			sendJSONError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ingredient); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
@@ -4,8 +4,18 @@ import (
 	"net/http"
 )
 
-func NewRouter(handler *WebHandler) http.Handler {
+func NewRouter(handler *WebHandler, adminToken string) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /orders", handler.HandleOrder)
+	mux.HandleFunc("POST /orders/{order_number}/cancel", allowAdmin(adminToken, handler.HandleCancelOrder))
+	mux.HandleFunc("GET /inventory", handler.HandleListInventory)
+	mux.HandleFunc("POST /inventory/{ingredient}/restock", requireAdmin(adminToken, handler.HandleRestock))
+	mux.HandleFunc("POST /admin/workers/{worker_name}/commands", requireAdmin(adminToken, handler.HandleWorkerCommand))
+	mux.HandleFunc("POST /admin/webhooks", requireAdmin(adminToken, handler.HandleCreateWebhook))
+	mux.HandleFunc("GET /admin/webhooks", requireAdmin(adminToken, handler.HandleListWebhooks))
+	mux.HandleFunc("GET /admin/webhooks/{id}", requireAdmin(adminToken, handler.HandleGetWebhook))
+	mux.HandleFunc("PUT /admin/webhooks/{id}", requireAdmin(adminToken, handler.HandleUpdateWebhook))
+	mux.HandleFunc("DELETE /admin/webhooks/{id}", requireAdmin(adminToken, handler.HandleDeleteWebhook))
+	mux.HandleFunc("GET /admin/webhooks/{id}/deliveries", requireAdmin(adminToken, handler.HandleListWebhookDeliveries))
 	return mux
 }
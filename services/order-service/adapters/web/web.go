@@ -3,6 +3,7 @@ package web
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"restaurant-system/services/order-service/domain/models"
@@ -13,14 +14,20 @@ import (
 )
 
 type WebHandler struct {
-	OrderService *service.OrderService
-	Logger       *logger.Logger
+	OrderService     *service.OrderService
+	AdminService     *service.AdminService
+	InventoryService *service.InventoryService
+	WebhookService   *service.WebhookService
+	Logger           *logger.Logger
 }
 
-func NewWebHandler(orderService *service.OrderService, serviceName string) *WebHandler {
+func NewWebHandler(orderService *service.OrderService, adminService *service.AdminService, inventoryService *service.InventoryService, webhookService *service.WebhookService, serviceName string) *WebHandler {
 	return &WebHandler{
-		OrderService: orderService,
-		Logger:       logger.New(serviceName),
+		OrderService:     orderService,
+		AdminService:     adminService,
+		InventoryService: inventoryService,
+		WebhookService:   webhookService,
+		Logger:           logger.New(serviceName),
 	}
 }
 
@@ -66,13 +73,26 @@ func (h *WebHandler) HandleOrder(w http.ResponseWriter, r *http.Request) {
 		request.Items,
 		request.TableNumber,
 		request.DeliveryAddress,
+		models.CustomerContact{
+			Phone:         request.CustomerPhone,
+			Email:         request.CustomerEmail,
+			Notifications: request.Notifications,
+		},
 	)
 	if err != nil {
 		h.Logger.Error("order_creation_failed", "Failed to create order", requestID, err)
 
 		// Check error type and return appropriate status code
+		var outOfStock *models.OutOfStockError
 		if strings.Contains(err.Error(), "validation") {
 			sendJSONError(w, http.StatusBadRequest, err.Error())
+		} else if errors.As(err, &outOfStock) {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":             outOfStock.Error(),
+				"unavailable_items": outOfStock.UnavailableItems(),
+				"shortages":         outOfStock.Shortages,
+			})
 		} else if strings.Contains(err.Error(), "RabbitMQ") {
 			// RabbitMQ errors are still considered successful order creation
 			// but we should log them as warnings
@@ -88,6 +108,50 @@ func (h *WebHandler) HandleOrder(w http.ResponseWriter, r *http.Request) {
 	h.Logger.Debug("order_created", "Order created successfully", requestID)
 
 	// Respond with the created order according to TZ specification
+	response := models.CreateOrderResponse{
+		OrderNumber:   order.OrderNumber,
+		Status:        order.Status,
+		TotalAmount:   order.TotalAmount,
+		TrackingToken: order.TrackingToken,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func (h *WebHandler) HandleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	orderNumber := r.PathValue("order_number")
+	h.Logger.Info("request_received", fmt.Sprintf("Received cancel request for order %s", orderNumber), requestID)
+
+	// Staff send the admin token, customers the tracking token they got with the order
+	trackingToken := r.Header.Get("X-Tracking-Token")
+	if !isAdmin(r) && trackingToken == "" {
+		sendJSONError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	order, err := h.OrderService.CancelOrder(r.Context(), orderNumber, trackingToken, isAdmin(r))
+	if err != nil {
+		h.Logger.Error("order_cancel_failed", "Failed to cancel order", requestID, err)
+
+		switch {
+		case strings.Contains(err.Error(), "validation"):
+			sendJSONError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, models.ErrOrderNotFound):
+			sendJSONError(w, http.StatusNotFound, "Order not found")
+		case errors.Is(err, models.ErrOrderNotCancellable):
+			sendJSONError(w, http.StatusConflict, err.Error())
+		default:
+			sendJSONError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
 	response := models.CreateOrderResponse{
 		OrderNumber: order.OrderNumber,
 		Status:      order.Status,
@@ -0,0 +1,177 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"restaurant-system/services/order-service/domain/models"
+	"strconv"
+	"strings"
+)
+
+func (h *WebHandler) HandleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	h.Logger.Info("request_received", "Received webhook subscription request", requestID)
+
+	var request models.WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("invalid_json", "Invalid JSON format", requestID, err)
+		sendJSONError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	subscription, err := h.WebhookService.CreateSubscription(r.Context(), request)
+	if err != nil {
+		h.Logger.Error("webhook_create_failed", "Failed to create webhook subscription", requestID, err)
+		sendWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func (h *WebHandler) HandleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	subscriptions, err := h.WebhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		h.Logger.Error("webhook_list_failed", "Failed to list webhook subscriptions", requestID, err)
+		sendJSONError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(subscriptions); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func (h *WebHandler) HandleGetWebhook(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.WebhookService.GetSubscription(r.Context(), id)
+	if err != nil {
+		h.Logger.Error("webhook_get_failed", "Failed to get webhook subscription", requestID, err)
+		sendWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func (h *WebHandler) HandleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	h.Logger.Info("request_received", fmt.Sprintf("Received update for webhook subscription %d", id), requestID)
+
+	var request models.WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("invalid_json", "Invalid JSON format", requestID, err)
+		sendJSONError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	subscription, err := h.WebhookService.UpdateSubscription(r.Context(), id, request)
+	if err != nil {
+		h.Logger.Error("webhook_update_failed", "Failed to update webhook subscription", requestID, err)
+		sendWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func (h *WebHandler) HandleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	h.Logger.Info("request_received", fmt.Sprintf("Received delete for webhook subscription %d", id), requestID)
+
+	if err := h.WebhookService.DeleteSubscription(r.Context(), id); err != nil {
+		h.Logger.Error("webhook_delete_failed", "Failed to delete webhook subscription", requestID, err)
+		sendWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebHandler) HandleListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	requestID := generateRequestID()
+	w.Header().Set("Content-Type", "application/json")
+
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			sendJSONError(w, http.StatusBadRequest, "limit must be an integer")
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.WebhookService.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		h.Logger.Error("webhook_deliveries_failed", "Failed to list webhook deliveries", requestID, err)
+		sendWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		h.Logger.Error("response_encode_failed", "Failed to encode response", requestID, err)
+	}
+}
+
+func webhookID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		sendJSONError(w, http.StatusBadRequest, "Invalid webhook id")
+		return 0, false
+	}
+	return id, true
+}
+
+func sendWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "validation"):
+		sendJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrWebhookNotFound):
+		sendJSONError(w, http.StatusNotFound, "Webhook subscription not found")
+	default:
+		sendJSONError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"restaurant-system/services/order-service/config"
 	"restaurant-system/services/order-service/domain/service"
 	"restaurant-system/services/order-service/utils/logger"
+	"restaurant-system/shared/clock"
 	"syscall"
 	"time"
 )
@@ -19,6 +20,8 @@ import (
 type Config struct {
 	Port          int
 	MaxConcurrent int
+	// TimeScale speeds up simulated time (60 = one minute per second)
+	TimeScale float64
 }
 
 func Start(ctx context.Context, cfg Config) error {
@@ -47,15 +50,41 @@ func Start(ctx context.Context, cfg Config) error {
 	}
 	defer rabbitClient.Close()
 
+	// Order-accepted status events go to the same fanout as the kitchen's
+	if err := rabbitClient.DeclareExchange("notifications_fanout", "fanout"); err != nil {
+		return fmt.Errorf("failed to declare notifications_fanout exchange: %w", err)
+	}
+
 	// Initialize repositories and services
 	orderRepo := postgres.NewPostgresOrderRepository(dbPool, serviceName)
-	rabbitPublisher := rabbitmq.NewRabbitMQPublisher(rabbitClient, serviceName)
+	inventoryRepo := postgres.NewPostgresInventoryRepository(dbPool, serviceName)
+	webhookRepo := postgres.NewPostgresWebhookRepository(dbPool, serviceName)
+	clk := clock.New(cfg.TimeScale)
+	rabbitPublisher := rabbitmq.NewRabbitMQPublisher(rabbitClient, clk, serviceName)
+	statusRelay := service.NewStatusRelay(postgres.NewPostgresStatusOutboxRepository(dbPool, serviceName), rabbitPublisher, serviceName)
+	orderService := service.NewOrderService(orderRepo, rabbitPublisher, statusRelay, clk)
+	inventoryService := service.NewInventoryService(inventoryRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+
+	// Control channel for kitchen workers
+	workerControl, err := rabbitmq.NewWorkerControlClient(rabbitClient, serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to set up worker control: %w", err)
+	}
+	adminService := service.NewAdminService(workerControl, clk)
+
+	if appConfig.AdminToken == "" {
+		logger.Info("admin_disabled", "ADMIN_TOKEN is not set, admin routes are disabled", "")
+	}
 
-	orderService := service.NewOrderService(orderRepo, rabbitPublisher)
+	// Status events queued in status_outbox go out until shutdown
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go statusRelay.Run(relayCtx)
 
 	// HTTP handler
-	webHandler := web.NewWebHandler(orderService, serviceName)
-	router := web.NewRouter(webHandler)
+	webHandler := web.NewWebHandler(orderService, adminService, inventoryService, webhookService, serviceName)
+	router := web.NewRouter(webHandler, appConfig.AdminToken)
 
 	// HTTP server
 	port := cfg.Port
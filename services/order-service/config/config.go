@@ -24,6 +24,8 @@ type RabbitMQConfig struct {
 type Config struct {
 	Database DatabaseConfig
 	RabbitMQ RabbitMQConfig
+	// AdminToken защищает /admin/* эндпоинты; без него они всегда отвечают 503
+	AdminToken string
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,6 +43,7 @@ func LoadConfig() (*Config, error) {
 			User:     getEnv("RABBITMQ_USER", "guest"),
 			Password: getEnv("RABBITMQ_PASSWORD", "guest"),
 		},
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
 	}
 
 	return config, nil
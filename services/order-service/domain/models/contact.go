@@ -0,0 +1,28 @@
+package models
+
+// Каналы, по которым клиенту можно отправить уведомление
+const (
+	NotifyChannelEmail = "email"
+	NotifyChannelSMS   = "sms"
+)
+
+// Языки, на которых notification-service умеет писать клиенту
+const (
+	LocaleEnglish = "en"
+	LocaleRussian = "ru"
+	LocaleKazakh  = "kk"
+)
+
+// контакты клиента, принимаем с апи вместе с заказом
+type CustomerContact struct {
+	Phone         *string
+	Email         *string
+	Notifications *NotificationPreferences
+}
+
+// NotificationPreferences — на какие статусы, по каким каналам и на каком языке уведомлять клиента
+type NotificationPreferences struct {
+	Channels []string `json:"channels,omitempty"`
+	Statuses []string `json:"statuses,omitempty"`
+	Locale   string   `json:"locale,omitempty"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrOrderNotCancellable = errors.New("order cannot be cancelled")
+	ErrIngredientNotFound  = errors.New("ingredient not found")
+)
+
+// db
+type Ingredient struct {
+	ID                int       `json:"id"`
+	Name              string    `json:"name"`
+	Unit              string    `json:"unit"`
+	StockQuantity     float64   `json:"stock_quantity"`
+	ReservedQuantity  float64   `json:"reserved_quantity"`
+	Available         float64   `json:"available"`
+	LowStockThreshold float64   `json:"low_stock_threshold"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// принимаем с апи
+type RestockRequest struct {
+	Quantity float64 `json:"quantity"`
+}
+
+// StockShortage describes one ingredient that cannot cover an order
+type StockShortage struct {
+	Ingredient string   `json:"ingredient"`
+	Required   float64  `json:"required"`
+	Available  float64  `json:"available"`
+	MenuItems  []string `json:"menu_items"`
+}
+
+// OutOfStockError is returned by CreateOrder when the reservation fails
+type OutOfStockError struct {
+	Shortages []StockShortage
+}
+
+func (e *OutOfStockError) Error() string {
+	var names []string
+	for _, shortage := range e.Shortages {
+		names = append(names, shortage.Ingredient)
+	}
+	return fmt.Sprintf("out of stock: %s", strings.Join(names, ", "))
+}
+
+// UnavailableItems lists the menu items affected by the shortages
+func (e *OutOfStockError) UnavailableItems() []string {
+	seen := make(map[string]bool)
+	var items []string
+	for _, shortage := range e.Shortages {
+		for _, item := range shortage.MenuItems {
+			if !seen[item] {
+				seen[item] = true
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -14,6 +14,8 @@ type OrderMessage struct {
 	Items           []OrderItemRequest
 	TotalAmount     float64
 	Priority        int
+	// CorrelationID начинает цепочку событий заказа, которую продолжает кухня
+	CorrelationID string
 }
 
 // принимаем с апи
@@ -23,6 +25,10 @@ type OrderCreatedRequest struct {
 	TableNumber     *int               `json:"table_number,omitempty"`
 	DeliveryAddress *string            `json:"delivery_address,omitempty"`
 	Items           []OrderItemRequest `json:"items"`
+
+	CustomerPhone *string                  `json:"customer_phone,omitempty"`
+	CustomerEmail *string                  `json:"customer_email,omitempty"`
+	Notifications *NotificationPreferences `json:"notifications,omitempty"`
 }
 
 //  принимаем с апи
@@ -37,6 +43,8 @@ type CreateOrderResponse struct {
 	OrderNumber string
 	Status      string
 	TotalAmount float64
+	// TrackingToken открывает публичный /track/{token} в tracking-service
+	TrackingToken string `json:",omitempty"`
 }
 
 // db
@@ -45,6 +53,7 @@ type Order struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	OrderNumber     string
+	TrackingToken   string
 	CustomerName    string
 	OrderType       string
 	TableNumber     *int
@@ -55,6 +64,12 @@ type Order struct {
 	ProcessedBy     *string
 	Items           []OrderItem
 	CompletedAt     *time.Time
+	CustomerPhone   *string
+	CustomerEmail   *string
+	// nil — клиент не оставил контактов, уведомлять некого
+	Notifications *NotificationPreferences
+	// CorrelationID не хранится в orders, только в событиях заказа
+	CorrelationID string
 }
 
 // db
@@ -77,3 +92,41 @@ type OrderStatusLog struct {
 	Notes     *string
 	CreatedAt time.Time // Add this field
 }
+
+// db, строка status_outbox: смена статуса, которую ещё нужно объявить в notifications_fanout
+type StatusEvent struct {
+	ID            int
+	OrderNumber   string
+	OrderType     string
+	OldStatus     string
+	NewStatus     string
+	CorrelationID string
+	ChangedAt     time.Time
+	Attempts      int
+}
+
+// команда воркеру кухни, принимаем с апи
+type WorkerCommandRequest struct {
+	Command    string   `json:"command"`
+	OrderTypes []string `json:"order_types,omitempty"`
+}
+
+// rabbit, уходит в control.<worker>
+type WorkerCommand struct {
+	Command    string    `json:"command"`
+	OrderTypes []string  `json:"order_types,omitempty"`
+	IssuedAt   time.Time `json:"issued_at"`
+}
+
+// rabbit, подтверждение от воркера
+type WorkerCommandAck struct {
+	WorkerName string    `json:"worker_name"`
+	Command    string    `json:"command"`
+	Accepted   bool      `json:"accepted"`
+	State      string    `json:"state"`
+	OrderTypes []string  `json:"order_types"`
+	InFlight   int       `json:"in_flight"`
+	Buffered   int       `json:"buffered"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrWebhookNotFound = errors.New("webhook subscription not found")
+
+// Статусы доставки вебхука в webhook_deliveries
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySent    = "sent"
+	WebhookDeliveryFailed  = "failed"
+)
+
+// db, подписка стороннего сервиса на события
+type WebhookSubscription struct {
+	ID         int      `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Secret подписывает тело запроса (HMAC-SHA256); отдаём его только при создании и смене
+	Secret    string    `json:"secret,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// принимаем с апи
+type WebhookSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Secret — если не задан при создании, генерируем сами
+	Secret *string `json:"secret,omitempty"`
+	Active *bool   `json:"active,omitempty"`
+}
+
+// db, одна попытка доставить событие подписчику
+type WebhookDelivery struct {
+	ID             int        `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "time"
+
+// Clock is the service's time source; durations may be scaled in simulation mode
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+}
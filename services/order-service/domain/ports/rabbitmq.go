@@ -1,7 +1,17 @@
 package ports
 
-import "restaurant-system/services/order-service/domain/models"
+import (
+	"context"
+	"restaurant-system/services/order-service/domain/models"
+)
 
 type RabbitMQPublisher interface {
 	PublishOrder(order *models.OrderMessage) error
+	// PublishStatusChanged announces a status change made by order-service on notifications_fanout
+	PublishStatusChanged(event models.StatusEvent) error
+}
+
+type WorkerControlPublisher interface {
+	// SendWorkerCommand публикует команду и ждёт подтверждения воркера до отмены ctx
+	SendWorkerCommand(ctx context.Context, workerName string, command *models.WorkerCommand) (*models.WorkerCommandAck, error)
 }
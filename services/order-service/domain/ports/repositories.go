@@ -6,8 +6,40 @@ import (
 )
 
 type OrderRepository interface {
+	// SaveOrderWithItems also reserves recipe ingredients and queues the received
+	// status event in the same transaction, and returns *models.OutOfStockError
+	// when stock is insufficient
 	SaveOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error
 	GetOrderByNumber(ctx context.Context, orderNumber string) (*models.Order, error)
 	GetOrderItems(ctx context.Context, orderID int) ([]models.OrderItem, error)
 	UpdateOrderStatus(ctx context.Context, orderID int, status string, processedBy string) error
+	// CancelOrder cancels a received order, releases its reservations and queues the
+	// cancelled status event; a non-empty trackingToken must match the order's,
+	// otherwise models.ErrOrderNotFound is returned
+	CancelOrder(ctx context.Context, orderNumber, trackingToken, changedBy string) (*models.Order, error)
+}
+
+// StatusOutboxRepository holds status events until they reach notifications_fanout
+type StatusOutboxRepository interface {
+	// ClaimDue returns unpublished events oldest first and moves their next attempt
+	// back with backoff, so an event that fails to publish is picked up again later
+	ClaimDue(ctx context.Context, limit int) ([]models.StatusEvent, error)
+	MarkPublished(ctx context.Context, id int) error
+}
+
+type InventoryRepository interface {
+	ListIngredients(ctx context.Context) ([]models.Ingredient, error)
+	Restock(ctx context.Context, name string, quantity float64) (*models.Ingredient, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	// GetSubscription returns models.ErrWebhookNotFound for unknown ids
+	GetSubscription(ctx context.Context, id int) (*models.WebhookSubscription, error)
+	// UpdateSubscription keeps the stored secret when subscription.Secret is empty
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int) error
+	// ListDeliveries returns the subscription's most recent deliveries first
+	ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]models.WebhookDelivery, error)
 }
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/domain/ports"
+)
+
+var validWorkerCommands = map[string]bool{
+	"pause":           true,
+	"resume":          true,
+	"drain":           true,
+	"set_order_types": true,
+}
+
+type AdminService struct {
+	WorkerControl ports.WorkerControlPublisher
+	Clock         ports.Clock
+}
+
+func NewAdminService(workerControl ports.WorkerControlPublisher, clock ports.Clock) *AdminService {
+	return &AdminService{WorkerControl: workerControl, Clock: clock}
+}
+
+// SendWorkerCommand validates the command and forwards it to the worker's control queue
+func (s *AdminService) SendWorkerCommand(ctx context.Context, workerName string, request models.WorkerCommandRequest) (*models.WorkerCommandAck, error) {
+	if err := validateWorkerCommand(workerName, request); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	command := &models.WorkerCommand{
+		Command:    request.Command,
+		OrderTypes: request.OrderTypes,
+		IssuedAt:   s.Clock.Now(),
+	}
+
+	return s.WorkerControl.SendWorkerCommand(ctx, workerName, command)
+}
+
+func validateWorkerCommand(workerName string, request models.WorkerCommandRequest) error {
+	if workerName == "" {
+		return fmt.Errorf("worker_name is required")
+	}
+	if !validWorkerCommands[request.Command] {
+		return fmt.Errorf("command must be one of: pause, resume, drain, set_order_types")
+	}
+
+	if request.Command != "set_order_types" {
+		if len(request.OrderTypes) > 0 {
+			return fmt.Errorf("order_types is only allowed for set_order_types")
+		}
+		return nil
+	}
+
+	for i, orderType := range request.OrderTypes {
+		if !validOrderTypes[orderType] {
+			return fmt.Errorf("order_types[%d] must be one of: dine_in, takeout, delivery", i)
+		}
+	}
+	return nil
+}
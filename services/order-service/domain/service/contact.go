@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"restaurant-system/services/order-service/domain/models"
+)
+
+var phoneRegex = regexp.MustCompile(`^\+?[1-9][0-9]{6,14}$`)
+
+var validNotifyStatuses = map[string]bool{
+	"received":  true,
+	"cooking":   true,
+	"ready":     true,
+	"completed": true,
+	"cancelled": true,
+}
+
+// по умолчанию клиенту интересно только, что заказ готов
+var defaultNotifyStatuses = []string{"ready"}
+
+var validLocales = map[string]bool{
+	models.LocaleEnglish: true,
+	models.LocaleRussian: true,
+	models.LocaleKazakh:  true,
+}
+
+// validateContact checks phone/email and fills in preference defaults.
+// Returns nil preferences when the customer left no contact.
+func validateContact(contact models.CustomerContact) (*models.NotificationPreferences, error) {
+	if contact.Phone != nil && !phoneRegex.MatchString(*contact.Phone) {
+		return nil, fmt.Errorf("customer_phone must be 7-15 digits, optionally starting with +")
+	}
+	if contact.Email != nil {
+		if len(*contact.Email) > 254 {
+			return nil, fmt.Errorf("customer_email must be 254 characters or less")
+		}
+		addr, err := mail.ParseAddress(*contact.Email)
+		if err != nil || addr.Address != *contact.Email {
+			return nil, fmt.Errorf("customer_email is not a valid email address")
+		}
+	}
+
+	hasPhone, hasEmail := contact.Phone != nil, contact.Email != nil
+	if !hasPhone && !hasEmail {
+		if contact.Notifications != nil {
+			return nil, fmt.Errorf("notifications require customer_phone or customer_email")
+		}
+		return nil, nil
+	}
+
+	preferences := models.NotificationPreferences{}
+	if contact.Notifications != nil {
+		preferences = *contact.Notifications
+	}
+
+	if len(preferences.Channels) == 0 {
+		if hasEmail {
+			preferences.Channels = append(preferences.Channels, models.NotifyChannelEmail)
+		}
+		if hasPhone {
+			preferences.Channels = append(preferences.Channels, models.NotifyChannelSMS)
+		}
+	}
+	for _, channel := range preferences.Channels {
+		switch channel {
+		case models.NotifyChannelEmail:
+			if !hasEmail {
+				return nil, fmt.Errorf("notifications channel email requires customer_email")
+			}
+		case models.NotifyChannelSMS:
+			if !hasPhone {
+				return nil, fmt.Errorf("notifications channel sms requires customer_phone")
+			}
+		default:
+			return nil, fmt.Errorf("notifications channel must be one of: email, sms")
+		}
+	}
+
+	if len(preferences.Statuses) == 0 {
+		preferences.Statuses = defaultNotifyStatuses
+	}
+	for _, status := range preferences.Statuses {
+		if !validNotifyStatuses[status] {
+			return nil, fmt.Errorf("notifications status must be one of: received, cooking, ready, completed, cancelled")
+		}
+	}
+
+	if preferences.Locale == "" {
+		preferences.Locale = models.LocaleEnglish
+	}
+	if !validLocales[preferences.Locale] {
+		return nil, fmt.Errorf("notifications locale must be one of: en, ru, kk")
+	}
+
+	return &preferences, nil
+}
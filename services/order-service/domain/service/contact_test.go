@@ -0,0 +1,83 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"restaurant-system/services/order-service/domain/models"
+)
+
+func TestValidateContact(t *testing.T) {
+	str := func(s string) *string { return &s }
+	prefs := func(channels, statuses []string, locale string) *models.NotificationPreferences {
+		return &models.NotificationPreferences{Channels: channels, Statuses: statuses, Locale: locale}
+	}
+
+	tests := []struct {
+		name    string
+		contact models.CustomerContact
+		want    *models.NotificationPreferences
+		wantErr string
+	}{
+		// no contact
+		{"nothing", models.CustomerContact{}, nil, ""},
+		{"preferences without contact", models.CustomerContact{Notifications: prefs(nil, []string{"ready"}, "")}, nil, "require customer_phone or customer_email"},
+
+		// phone
+		{"phone with plus", models.CustomerContact{Phone: str("+77011234567")}, prefs([]string{"sms"}, []string{"ready"}, "en"), ""},
+		{"shortest phone", models.CustomerContact{Phone: str("1234567")}, prefs([]string{"sms"}, []string{"ready"}, "en"), ""},
+		{"longest phone", models.CustomerContact{Phone: str("123456789012345")}, prefs([]string{"sms"}, []string{"ready"}, "en"), ""},
+		{"phone too short", models.CustomerContact{Phone: str("123456")}, nil, "customer_phone"},
+		{"phone too long", models.CustomerContact{Phone: str("1234567890123456")}, nil, "customer_phone"},
+		{"phone starting with 0", models.CustomerContact{Phone: str("07011234567")}, nil, "customer_phone"},
+		{"phone with spaces", models.CustomerContact{Phone: str("+7 701 123 4567")}, nil, "customer_phone"},
+		{"empty phone", models.CustomerContact{Phone: str("")}, nil, "customer_phone"},
+
+		// email
+		{"email", models.CustomerContact{Email: str("alice@example.com")}, prefs([]string{"email"}, []string{"ready"}, "en"), ""},
+		{"email with display name", models.CustomerContact{Email: str("Alice <alice@example.com>")}, nil, "customer_email"},
+		{"email without domain", models.CustomerContact{Email: str("alice")}, nil, "customer_email"},
+		{"email with header injection", models.CustomerContact{Email: str("alice@example.com\r\nBcc: x@example.com")}, nil, "customer_email"},
+		{"email too long", models.CustomerContact{Email: str(strings.Repeat("a", 243) + "@example.com")}, nil, "254 characters"},
+
+		// channels must match the contacts given
+		{"both contacts default to both channels", models.CustomerContact{Phone: str("+77011234567"), Email: str("alice@example.com")},
+			prefs([]string{"email", "sms"}, []string{"ready"}, "en"), ""},
+		{"chosen channel", models.CustomerContact{Phone: str("+77011234567"), Email: str("alice@example.com"), Notifications: prefs([]string{"sms"}, nil, "")},
+			prefs([]string{"sms"}, []string{"ready"}, "en"), ""},
+		{"email channel without email", models.CustomerContact{Phone: str("+77011234567"), Notifications: prefs([]string{"email"}, nil, "")}, nil, "requires customer_email"},
+		{"sms channel without phone", models.CustomerContact{Email: str("alice@example.com"), Notifications: prefs([]string{"sms"}, nil, "")}, nil, "requires customer_phone"},
+		{"unknown channel", models.CustomerContact{Email: str("alice@example.com"), Notifications: prefs([]string{"push"}, nil, "")}, nil, "email, sms"},
+
+		// statuses and locale
+		{"chosen statuses and locale", models.CustomerContact{Email: str("alice@example.com"), Notifications: prefs(nil, []string{"cooking", "ready"}, "kk")},
+			prefs([]string{"email"}, []string{"cooking", "ready"}, "kk"), ""},
+		{"unknown status", models.CustomerContact{Email: str("alice@example.com"), Notifications: prefs(nil, []string{"eaten"}, "")}, nil, "notifications status"},
+		{"unknown locale", models.CustomerContact{Email: str("alice@example.com"), Notifications: prefs(nil, nil, "de")}, nil, "en, ru, kk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateContact(tt.contact)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateContact: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("preferences = %+v, want %+v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if !slices.Equal(got.Channels, tt.want.Channels) || !slices.Equal(got.Statuses, tt.want.Statuses) || got.Locale != tt.want.Locale {
+				t.Errorf("preferences = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/domain/ports"
+)
+
+type InventoryService struct {
+	InventoryRepository ports.InventoryRepository
+}
+
+func NewInventoryService(repo ports.InventoryRepository) *InventoryService {
+	return &InventoryService{InventoryRepository: repo}
+}
+
+func (s *InventoryService) ListIngredients(ctx context.Context) ([]models.Ingredient, error) {
+	return s.InventoryRepository.ListIngredients(ctx)
+}
+
+func (s *InventoryService) Restock(ctx context.Context, name string, request models.RestockRequest) (*models.Ingredient, error) {
+	if name == "" {
+		return nil, fmt.Errorf("validation failed: ingredient name is required")
+	}
+	if request.Quantity <= 0 {
+		return nil, fmt.Errorf("validation failed: quantity must be positive")
+	}
+	return s.InventoryRepository.Restock(ctx, name, request.Quantity)
+}
@@ -2,32 +2,41 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"regexp"
 	"restaurant-system/services/order-service/domain/models"
 	"restaurant-system/services/order-service/domain/ports"
+	"restaurant-system/shared/events"
 	"time"
 )
 
 type OrderService struct {
 	OrderRepository    ports.OrderRepository
 	RabbitMQPublisher  ports.RabbitMQPublisher
+	StatusRelay        *StatusRelay
 	OrderNumberService *OrderNumberService
 }
 
-func NewOrderService(repo ports.OrderRepository, publisher ports.RabbitMQPublisher) *OrderService {
+func NewOrderService(repo ports.OrderRepository, publisher ports.RabbitMQPublisher, relay *StatusRelay, clock ports.Clock) *OrderService {
 	return &OrderService{
 		OrderRepository:    repo,
 		RabbitMQPublisher:  publisher,
-		OrderNumberService: NewOrderNumberService(repo),
+		StatusRelay:        relay,
+		OrderNumberService: NewOrderNumberService(repo, clock),
 	}
 }
 
-func (s *OrderService) CreateOrder(ctx context.Context, customerName, orderType string, items []models.OrderItemRequest, tableNumber *int, deliveryAddress *string) (*models.Order, error) {
+func (s *OrderService) CreateOrder(ctx context.Context, customerName, orderType string, items []models.OrderItemRequest, tableNumber *int, deliveryAddress *string, contact models.CustomerContact) (*models.Order, error) {
 	// Validate order
 	if err := validateOrder(customerName, orderType, items, tableNumber, deliveryAddress); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	preferences, err := validateContact(contact)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
 
 	// Calculate total amount and priority
 	totalAmount := calculateTotalAmount(items)
@@ -39,15 +48,33 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerName, orderType
 		return nil, fmt.Errorf("failed to generate order number: %w", err)
 	}
 
+	// Order numbers are sequential, so customers track by a random token instead
+	trackingToken, err := generateTrackingToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tracking token: %w", err)
+	}
+
+	// The order's events share one correlation id, starting with the received announcement
+	correlationID, err := events.NewEventID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate correlation id: %w", err)
+	}
+
 	// Create order object
 	order := &models.Order{
 		OrderNumber:     orderNumber,
+		TrackingToken:   trackingToken,
 		CustomerName:    customerName,
 		OrderType:       orderType,
 		TableNumber:     tableNumber,
 		DeliveryAddress: deliveryAddress,
 		TotalAmount:     totalAmount,
 		Priority:        priority,
+		Status:          "received",
+		CustomerPhone:   contact.Phone,
+		CustomerEmail:   contact.Email,
+		Notifications:   preferences,
+		CorrelationID:   correlationID,
 	}
 	var itemsDb []models.OrderItem
 	for _, item := range items {
@@ -64,6 +91,9 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerName, orderType
 	if err != nil {
 		return nil, fmt.Errorf("failed to save order: %w", err)
 	}
+	// The received status event was queued with the order
+	s.StatusRelay.Notify()
+
 	orderMes := &models.OrderMessage{
 		OrderNumber:     orderNumber,
 		CustomerName:    customerName,
@@ -72,6 +102,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerName, orderType
 		DeliveryAddress: deliveryAddress,
 		TotalAmount:     totalAmount,
 		Priority:        priority,
+		CorrelationID:   order.CorrelationID,
 	}
 	// Publish to RabbitMQ
 	err = s.RabbitMQPublisher.PublishOrder(orderMes)
@@ -84,26 +115,62 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerName, orderType
 	return order, nil
 }
 
+// CancelOrder cancels an order that is still waiting for the kitchen and releases its ingredients.
+// Staff cancel any order; customers (byStaff false) must present the order's tracking token.
+func (s *OrderService) CancelOrder(ctx context.Context, orderNumber, trackingToken string, byStaff bool) (*models.Order, error) {
+	if orderNumber == "" {
+		return nil, fmt.Errorf("validation failed: order_number is required")
+	}
+
+	changedBy := "staff"
+	if !byStaff {
+		if trackingToken == "" {
+			return nil, models.ErrOrderNotFound
+		}
+		changedBy = "customer"
+	} else {
+		trackingToken = ""
+	}
+
+	order, err := s.OrderRepository.CancelOrder(ctx, orderNumber, trackingToken, changedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cancelled status event was queued with the cancel
+	s.StatusRelay.Notify()
+
+	return order, nil
+}
+
 // OrderNumberService handles transactional order number generation
 type OrderNumberService struct {
-	repo ports.OrderRepository
+	repo  ports.OrderRepository
+	clock ports.Clock
 }
 
-func NewOrderNumberService(repo ports.OrderRepository) *OrderNumberService {
-	return &OrderNumberService{repo: repo}
+func NewOrderNumberService(repo ports.OrderRepository, clock ports.Clock) *OrderNumberService {
+	return &OrderNumberService{repo: repo, clock: clock}
 }
 
 func (s *OrderNumberService) GenerateOrderNumber(ctx context.Context) (string, error) {
-	datePrefix := time.Now().UTC().Format("20060102")
+	now := s.clock.Now()
+	datePrefix := now.UTC().Format("20060102")
 
 	// This should be implemented with database sequence or atomic counter
 	// For now using timestamp-based approach
-	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
+	timestamp := now.UnixNano() / int64(time.Millisecond)
 	sequence := timestamp % 1000
 
 	return fmt.Sprintf("ORD_%s_%03d", datePrefix, sequence), nil
 }
 
+var validOrderTypes = map[string]bool{
+	"dine_in":  true,
+	"takeout":  true,
+	"delivery": true,
+}
+
 // Enhanced validation function
 func validateOrder(customerName, orderType string, items []models.OrderItemRequest, tableNumber *int, deliveryAddress *string) error {
 	// Validate customer name
@@ -121,11 +188,6 @@ func validateOrder(customerName, orderType string, items []models.OrderItemReque
 	}
 
 	// Validate order type
-	validOrderTypes := map[string]bool{
-		"dine_in":  true,
-		"takeout":  true,
-		"delivery": true,
-	}
 	if !validOrderTypes[orderType] {
 		return fmt.Errorf("order_type must be one of: dine_in, takeout, delivery")
 	}
@@ -188,6 +250,14 @@ func validateOrder(customerName, orderType string, items []models.OrderItemReque
 }
 
 // Helper functions
+func generateTrackingToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 func calculateTotalAmount(items []models.OrderItemRequest) float64 {
 	var total float64
 	for _, item := range items {
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"restaurant-system/services/order-service/domain/ports"
+	"restaurant-system/services/order-service/utils/logger"
+	"time"
+)
+
+const (
+	// events are published by the polling loop; new events wake it up early
+	statusRelayPollInterval = time.Second
+	statusRelayBatchSize    = 50
+)
+
+// StatusRelay publishes the status events order-service queues in status_outbox
+// together with the change itself, so a broker outage delays announcements
+// instead of losing them
+type StatusRelay struct {
+	repo      ports.StatusOutboxRepository
+	publisher ports.RabbitMQPublisher
+	logger    *logger.Logger
+	wake      chan struct{}
+}
+
+func NewStatusRelay(repo ports.StatusOutboxRepository, publisher ports.RabbitMQPublisher, serviceName string) *StatusRelay {
+	return &StatusRelay{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger.New(serviceName),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Notify tells the relay a new event was committed
+func (r *StatusRelay) Notify() {
+	if r == nil {
+		return
+	}
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes queued events until ctx is cancelled
+func (r *StatusRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(statusRelayPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+		r.publishDue(ctx)
+	}
+}
+
+func (r *StatusRelay) publishDue(ctx context.Context) {
+	statusEvents, err := r.repo.ClaimDue(ctx, statusRelayBatchSize)
+	if err != nil {
+		r.logger.Error("status_outbox_claim_failed", "Failed to load queued status events", "", err)
+		return
+	}
+
+	for _, event := range statusEvents {
+		if err := r.publisher.PublishStatusChanged(event); err != nil {
+			// the broker is most likely down; the claim already scheduled the retries
+			r.logger.Error("status_publish_deferred",
+				fmt.Sprintf("Status event %s of order %s not published (attempt %d), will retry", event.NewStatus, event.OrderNumber, event.Attempts),
+				event.OrderNumber, err)
+			return
+		}
+		if err := r.repo.MarkPublished(ctx, event.ID); err != nil {
+			// the event goes out again after its backoff; subscribers drop the repeat by event id
+			r.logger.Error("status_outbox_update_failed", "Failed to mark status event published", event.OrderNumber, err)
+		}
+	}
+}
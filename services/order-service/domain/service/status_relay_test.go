@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/domain/ports"
+)
+
+type fakeOutbox struct {
+	ports.StatusOutboxRepository
+	due       []models.StatusEvent
+	published []int
+}
+
+func (f *fakeOutbox) ClaimDue(ctx context.Context, limit int) ([]models.StatusEvent, error) {
+	return f.due, nil
+}
+
+func (f *fakeOutbox) MarkPublished(ctx context.Context, id int) error {
+	f.published = append(f.published, id)
+	return nil
+}
+
+type fakePublisher struct {
+	ports.RabbitMQPublisher
+	failOn int
+	sent   []int
+}
+
+func (f *fakePublisher) PublishStatusChanged(event models.StatusEvent) error {
+	if event.ID == f.failOn {
+		return errors.New("broker unavailable")
+	}
+	f.sent = append(f.sent, event.ID)
+	return nil
+}
+
+func TestStatusRelayPublishDue(t *testing.T) {
+	due := []models.StatusEvent{
+		{ID: 1, OrderNumber: "ORD_1", NewStatus: "received"},
+		{ID: 2, OrderNumber: "ORD_2", NewStatus: "received"},
+		{ID: 3, OrderNumber: "ORD_3", NewStatus: "cancelled"},
+	}
+
+	tests := []struct {
+		name   string
+		failOn int
+		want   []int
+	}{
+		{"all published in order", 0, []int{1, 2, 3}},
+		{"stops at the first failure", 2, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeOutbox{due: due}
+			publisher := &fakePublisher{failOn: tt.failOn}
+			relay := NewStatusRelay(outbox, publisher, "test")
+
+			relay.publishDue(context.Background())
+
+			if !slices.Equal(publisher.sent, tt.want) {
+				t.Errorf("sent %v, want %v", publisher.sent, tt.want)
+			}
+			// only events that reached the broker leave the outbox
+			if !slices.Equal(outbox.published, tt.want) {
+				t.Errorf("marked published %v, want %v", outbox.published, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"restaurant-system/services/order-service/domain/models"
+	"restaurant-system/services/order-service/domain/ports"
+	"restaurant-system/shared/events"
+	"slices"
+)
+
+const (
+	minWebhookSecretLength = 16
+	defaultDeliveryLimit   = 50
+	maxDeliveryLimit       = 500
+)
+
+// события, на которые можно подписаться — всё, что уходит в notifications_fanout
+var webhookEventTypes = []string{events.TypeOrderStatusChanged, events.TypeLowStock}
+
+type WebhookService struct {
+	WebhookRepository ports.WebhookRepository
+}
+
+func NewWebhookService(repo ports.WebhookRepository) *WebhookService {
+	return &WebhookService{WebhookRepository: repo}
+}
+
+// CreateSubscription возвращает подписку вместе с секретом — позже его уже не показать
+func (s *WebhookService) CreateSubscription(ctx context.Context, request models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	subscription, err := buildSubscription(request)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+	if request.Active == nil {
+		subscription.Active = true
+	}
+
+	if err := s.WebhookRepository.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.WebhookRepository.ListSubscriptions(ctx)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id int) (*models.WebhookSubscription, error) {
+	return s.WebhookRepository.GetSubscription(ctx, id)
+}
+
+// UpdateSubscription заменяет url и события; секрет меняется, только если передан новый
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id int, request models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	subscription, err := buildSubscription(request)
+	if err != nil {
+		return nil, err
+	}
+	subscription.ID = id
+
+	if request.Active == nil {
+		current, err := s.WebhookRepository.GetSubscription(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		subscription.Active = current.Active
+	}
+
+	if err := s.WebhookRepository.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int) error {
+	return s.WebhookRepository.DeleteSubscription(ctx, id)
+}
+
+// ListDeliveries отдаёт историю доставок подписки, свежие первыми
+func (s *WebhookService) ListDeliveries(ctx context.Context, id int, limit int) ([]models.WebhookDelivery, error) {
+	if limit == 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit < 0 || limit > maxDeliveryLimit {
+		return nil, fmt.Errorf("validation failed: limit must be between 1 and %d", maxDeliveryLimit)
+	}
+
+	// 404 для несуществующей подписки, а не пустой список
+	if _, err := s.WebhookRepository.GetSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.WebhookRepository.ListDeliveries(ctx, id, limit)
+}
+
+func buildSubscription(request models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	target, err := url.Parse(request.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("validation failed: url must be an absolute http or https URL")
+	}
+
+	if len(request.EventTypes) == 0 {
+		return nil, fmt.Errorf("validation failed: at least one event type is required")
+	}
+	eventTypes := make([]string, 0, len(request.EventTypes))
+	for _, eventType := range request.EventTypes {
+		if !slices.Contains(webhookEventTypes, eventType) {
+			return nil, fmt.Errorf("validation failed: unsupported event type %q", eventType)
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	subscription := &models.WebhookSubscription{
+		URL:        target.String(),
+		EventTypes: eventTypes,
+	}
+	if request.Secret != nil {
+		if len(*request.Secret) < minWebhookSecretLength {
+			return nil, fmt.Errorf("validation failed: secret must be at least %d characters", minWebhookSecretLength)
+		}
+		subscription.Secret = *request.Secret
+	}
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+	return subscription, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
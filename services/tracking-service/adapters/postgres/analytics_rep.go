@@ -0,0 +1,227 @@
+// services/tracking-service/adapters/postgres/analytics_rep.go
+package postgres
+
+import (
+	"context"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/services/tracking-service/utils/logger"
+	"restaurant-system/shared/kitchen"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// orderDeadline is the kitchen's deadline: priority-based max wait plus cook time
+// for the order type. Both are simulated time, so the query must pass the time scale as $4.
+var orderDeadline = kitchen.DeadlineSQL("o.created_at", "o.type", "o.priority", "$4::float8")
+
+type PostgresAnalyticsRepository struct {
+	db     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresAnalyticsRepository(db *pgxpool.Pool, serviceName string) ports.AnalyticsRepository {
+	return &PostgresAnalyticsRepository{
+		db:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+func (r *PostgresAnalyticsRepository) GetOrdersByPeriod(ctx context.Context, from, to time.Time, bucket string) ([]models.PeriodStats, error) {
+	query := `
+		SELECT
+			date_trunc($3, created_at) AS period_start,
+			count(*),
+			coalesce(sum(total_amount) FILTER (WHERE status <> 'cancelled'), 0)::float8
+		FROM orders
+		WHERE created_at >= $1
+		  AND created_at < $2
+		GROUP BY period_start
+		ORDER BY period_start
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to, bucket)
+	if err != nil {
+		r.Logger.Error("get_orders_by_period_failed", "Failed to get orders by period", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []models.PeriodStats{}
+	for rows.Next() {
+		var period models.PeriodStats
+		if err := rows.Scan(&period.PeriodStart, &period.Orders, &period.Revenue); err != nil {
+			r.Logger.Error("scan_period_failed", "Failed to scan period stats", "", err)
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over period stats", "", err)
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *PostgresAnalyticsRepository) GetStatusDurations(ctx context.Context, from, to time.Time) ([]models.StatusDuration, error) {
+	// time in a status runs until the next log entry of the same order;
+	// the current status of open orders has no end yet and is skipped
+	query := `
+		WITH spans AS (
+			SELECT
+				l.status,
+				extract(epoch FROM lead(l.changed_at) OVER (PARTITION BY l.order_id ORDER BY l.changed_at, l.id) - l.changed_at) AS seconds
+			FROM order_status_log l
+			JOIN orders o ON o.id = l.order_id
+			WHERE o.created_at >= $1
+			  AND o.created_at < $2
+		)
+		SELECT
+			status,
+			count(*),
+			avg(seconds)::float8,
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY seconds)::float8
+		FROM spans
+		WHERE seconds IS NOT NULL
+		GROUP BY status
+		ORDER BY status
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		r.Logger.Error("get_status_durations_failed", "Failed to get status durations", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	durations := []models.StatusDuration{}
+	for rows.Next() {
+		var duration models.StatusDuration
+		if err := rows.Scan(&duration.Status, &duration.Samples, &duration.AvgSeconds, &duration.P95Seconds); err != nil {
+			r.Logger.Error("scan_status_duration_failed", "Failed to scan status duration", "", err)
+			return nil, err
+		}
+		durations = append(durations, duration)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over status durations", "", err)
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+func (r *PostgresAnalyticsRepository) GetBreakdown(ctx context.Context, from, to time.Time) ([]models.BreakdownEntry, error) {
+	query := `
+		WITH ready AS (
+			SELECT order_id, min(changed_at) AS ready_at
+			FROM order_status_log
+			WHERE status = 'ready'
+			GROUP BY order_id
+		)
+		SELECT
+			o.type,
+			coalesce(o.priority, 1) AS priority,
+			count(*),
+			count(*) FILTER (WHERE o.status = 'cancelled'),
+			coalesce(sum(o.total_amount) FILTER (WHERE o.status <> 'cancelled'), 0)::float8,
+			avg(extract(epoch FROM ready.ready_at - o.created_at))::float8
+		FROM orders o
+		LEFT JOIN ready ON ready.order_id = o.id
+		WHERE o.created_at >= $1
+		  AND o.created_at < $2
+		GROUP BY o.type, coalesce(o.priority, 1)
+		ORDER BY o.type, priority DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		r.Logger.Error("get_breakdown_failed", "Failed to get order breakdown", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := []models.BreakdownEntry{}
+	for rows.Next() {
+		var entry models.BreakdownEntry
+		err := rows.Scan(
+			&entry.OrderType,
+			&entry.Priority,
+			&entry.Orders,
+			&entry.Cancelled,
+			&entry.Revenue,
+			&entry.AvgSecondsToReady,
+		)
+		if err != nil {
+			r.Logger.Error("scan_breakdown_failed", "Failed to scan order breakdown", "", err)
+			return nil, err
+		}
+		breakdown = append(breakdown, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over order breakdown", "", err)
+		return nil, err
+	}
+
+	return breakdown, nil
+}
+
+func (r *PostgresAnalyticsRepository) GetLateOrders(ctx context.Context, from, to, now time.Time, timeScale float64) ([]models.LateOrders, error) {
+	query := `
+		WITH ready AS (
+			SELECT order_id, min(changed_at) AS ready_at
+			FROM order_status_log
+			WHERE status = 'ready'
+			GROUP BY order_id
+		),
+		deadlines AS (
+			SELECT
+				o.type,
+				o.status,
+				ready.ready_at,
+				` + orderDeadline + ` AS deadline
+			FROM orders o
+			LEFT JOIN ready ON ready.order_id = o.id
+			WHERE o.created_at >= $1
+			  AND o.created_at < $2
+			  AND o.status <> 'cancelled'
+		)
+		SELECT
+			type,
+			count(*),
+			count(*) FILTER (WHERE ready_at > deadline),
+			count(*) FILTER (WHERE ready_at IS NULL AND $3 > deadline)
+		FROM deadlines
+		GROUP BY type
+		ORDER BY type
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to, now, timeScale)
+	if err != nil {
+		r.Logger.Error("get_late_orders_failed", "Failed to get late orders", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	late := []models.LateOrders{}
+	for rows.Next() {
+		var entry models.LateOrders
+		if err := rows.Scan(&entry.OrderType, &entry.Orders, &entry.Late, &entry.LateOpen); err != nil {
+			r.Logger.Error("scan_late_orders_failed", "Failed to scan late orders", "", err)
+			return nil, err
+		}
+		late = append(late, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over late orders", "", err)
+		return nil, err
+	}
+
+	return late, nil
+}
@@ -0,0 +1,107 @@
+// services/tracking-service/adapters/postgres/metrics_rep.go
+package postgres
+
+import (
+	"context"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/services/tracking-service/utils/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresMetricsRepository struct {
+	db     *pgxpool.Pool
+	Logger *logger.Logger
+}
+
+func NewPostgresMetricsRepository(db *pgxpool.Pool, serviceName string) ports.MetricsRepository {
+	return &PostgresMetricsRepository{
+		db:     db,
+		Logger: logger.New(serviceName),
+	}
+}
+
+func (r *PostgresMetricsRepository) GetWorkerMetrics(ctx context.Context, workerName string, from, to time.Time) ([]models.WorkerMetrics, error) {
+	query := `
+		SELECT
+			worker_name,
+			count(*),
+			avg(estimated_seconds)::float8,
+			avg(actual_seconds)::float8,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY actual_seconds)::float8,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY actual_seconds)::float8,
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY actual_seconds)::float8,
+			count(*) FILTER (WHERE actual_seconds > estimated_seconds)
+		FROM order_cook_metrics
+		WHERE finished_at >= $1
+		  AND finished_at < $2
+		  AND ($3 = '' OR worker_name = $3)
+		GROUP BY worker_name
+		ORDER BY worker_name
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to, workerName)
+	if err != nil {
+		r.Logger.Error("get_worker_metrics_failed", "Failed to get worker metrics", workerName, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metrics []models.WorkerMetrics
+	for rows.Next() {
+		entry := models.WorkerMetrics{From: from, To: to}
+		err := rows.Scan(
+			&entry.WorkerName,
+			&entry.OrdersCooked,
+			&entry.AvgEstimatedSeconds,
+			&entry.AvgActualSeconds,
+			&entry.P50ActualSeconds,
+			&entry.P90ActualSeconds,
+			&entry.P95ActualSeconds,
+			&entry.OverdueCount,
+		)
+		if err != nil {
+			r.Logger.Error("scan_worker_metrics_failed", "Failed to scan worker metrics", workerName, err)
+			return nil, err
+		}
+		metrics = append(metrics, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over worker metrics", workerName, err)
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+func (r *PostgresMetricsRepository) GetCookTimeStats(ctx context.Context, orderType string, limit int) (models.CookTimeStats, error) {
+	query := `
+		SELECT
+			count(*),
+			coalesce(avg(actual_seconds), 0)::float8,
+			coalesce(stddev_samp(actual_seconds), 0)::float8
+		FROM (
+			SELECT actual_seconds
+			FROM order_cook_metrics
+			WHERE order_type = $1
+			ORDER BY finished_at DESC
+			LIMIT $2
+		) recent
+	`
+
+	var stats models.CookTimeStats
+	err := r.db.QueryRow(ctx, query, orderType, limit).Scan(
+		&stats.Samples,
+		&stats.AvgSeconds,
+		&stats.StddevSeconds,
+	)
+	if err != nil {
+		r.Logger.Error("get_cook_time_stats_failed", "Failed to get cook time stats", orderType, err)
+		return models.CookTimeStats{}, err
+	}
+
+	return stats, nil
+}
@@ -6,7 +6,9 @@ import (
 	"restaurant-system/services/tracking-service/domain/models"
 	"restaurant-system/services/tracking-service/domain/ports"
 	"restaurant-system/services/tracking-service/utils/logger"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -26,9 +28,9 @@ func (r *PostgresOrderRepository) GetOrderByNumber(ctx context.Context, orderNum
 	query := `
 		SELECT 
 			number, 
+			type,
 			status, 
 			updated_at, 
-			completed_at as estimated_completion, 
 			processed_by
 		FROM orders 
 		WHERE number = $1
@@ -37,9 +39,9 @@ func (r *PostgresOrderRepository) GetOrderByNumber(ctx context.Context, orderNum
 	var statusResponse models.OrderStatusResponse
 	err := r.db.QueryRow(ctx, query, orderNumber).Scan(
 		&statusResponse.OrderNumber,
+		&statusResponse.OrderType,
 		&statusResponse.CurrentStatus,
 		&statusResponse.UpdatedAt,
-		&statusResponse.EstimatedCompletion,
 		&statusResponse.ProcessedBy,
 	)
 	if err != nil {
@@ -55,11 +57,12 @@ func (r *PostgresOrderRepository) GetOrderStatusHistory(ctx context.Context, ord
 		SELECT 
 			osl.status, 
 			osl.changed_at as timestamp, 
-			osl.changed_by
+			osl.changed_by,
+			osl.notes
 		FROM order_status_log osl
 		JOIN orders o ON osl.order_id = o.id
 		WHERE o.number = $1
-		ORDER BY osl.changed_at ASC
+		ORDER BY osl.changed_at ASC, osl.id ASC
 	`
 
 	rows, err := r.db.Query(ctx, query, orderNumber)
@@ -76,6 +79,7 @@ func (r *PostgresOrderRepository) GetOrderStatusHistory(ctx context.Context, ord
 			&entry.Status,
 			&entry.Timestamp,
 			&entry.ChangedBy,
+			&entry.Notes,
 		)
 		if err != nil {
 			r.Logger.Error("scan_status_history_failed", "Failed to scan status history", orderNumber, err)
@@ -91,3 +95,310 @@ func (r *PostgresOrderRepository) GetOrderStatusHistory(ctx context.Context, ord
 
 	return history, nil
 }
+
+func (r *PostgresOrderRepository) GetActiveOrders(ctx context.Context, readySince time.Time) ([]models.OrderStatusResponse, error) {
+	query := `
+		SELECT 
+			number, 
+			type,
+			status, 
+			updated_at, 
+			processed_by
+		FROM orders 
+		WHERE status IN ('received', 'cooking')
+		   OR (status = 'ready' AND updated_at >= $1)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, readySince)
+	if err != nil {
+		r.Logger.Error("get_active_orders_failed", "Failed to get active orders", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.OrderStatusResponse
+	for rows.Next() {
+		var order models.OrderStatusResponse
+		err := rows.Scan(
+			&order.OrderNumber,
+			&order.OrderType,
+			&order.CurrentStatus,
+			&order.UpdatedAt,
+			&order.ProcessedBy,
+		)
+		if err != nil {
+			r.Logger.Error("scan_active_order_failed", "Failed to scan active order", "", err)
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over active orders", "", err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// GetETAInputs counts the queue in broker order: the kitchen's per-type queues are
+// priority queues, so an order waits for every received order of its type with a
+// higher priority, and for older ones with the same priority
+func (r *PostgresOrderRepository) GetETAInputs(ctx context.Context, orderNumber string) (models.ETAInputs, error) {
+	inputs, err := r.GetETAInputsByNumbers(ctx, []string{orderNumber})
+	if err != nil {
+		return models.ETAInputs{}, err
+	}
+	if len(inputs) == 0 {
+		return models.ETAInputs{}, pgx.ErrNoRows
+	}
+	return inputs[0], nil
+}
+
+func (r *PostgresOrderRepository) GetETAInputsByNumbers(ctx context.Context, orderNumbers []string) ([]models.ETAInputs, error) {
+	query := `
+		SELECT
+			o.number,
+			o.type,
+			o.status,
+			o.updated_at,
+			o.completed_at,
+			(SELECT count(*)
+			 FROM orders q
+			 WHERE q.type = o.type
+			   AND q.status = 'received'
+			   AND q.id <> o.id
+			   AND (coalesce(q.priority, 1) > coalesce(o.priority, 1)
+			        OR (coalesce(q.priority, 1) = coalesce(o.priority, 1)
+			            AND (q.created_at, q.id) < (o.created_at, o.id)))),
+			(SELECT count(*)
+			 FROM orders c
+			 WHERE c.type = o.type
+			   AND c.status = 'cooking'
+			   AND c.id <> o.id)
+		FROM orders o
+		WHERE o.number = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, orderNumbers)
+	if err != nil {
+		r.Logger.Error("get_eta_inputs_failed", "Failed to get ETA inputs", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var inputs []models.ETAInputs
+	for rows.Next() {
+		var in models.ETAInputs
+		err := rows.Scan(
+			&in.OrderNumber,
+			&in.OrderType,
+			&in.Status,
+			&in.UpdatedAt,
+			&in.CompletedAt,
+			&in.QueuedAhead,
+			&in.CookingNow,
+		)
+		if err != nil {
+			r.Logger.Error("scan_eta_inputs_failed", "Failed to scan ETA inputs", "", err)
+			return nil, err
+		}
+		inputs = append(inputs, in)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over ETA inputs", "", err)
+		return nil, err
+	}
+
+	return inputs, nil
+}
+
+func (r *PostgresOrderRepository) GetOrderStateAt(ctx context.Context, orderNumber string, at time.Time) (models.OrderStateAt, error) {
+	query := `
+		SELECT 
+			o.number,
+			osl.status,
+			osl.changed_at,
+			coalesce(osl.changed_by, '')
+		FROM order_status_log osl
+		JOIN orders o ON osl.order_id = o.id
+		WHERE o.number = $1
+		  AND osl.changed_at <= $2
+		ORDER BY osl.changed_at DESC, osl.id DESC
+		LIMIT 1
+	`
+
+	state := models.OrderStateAt{At: at}
+	err := r.db.QueryRow(ctx, query, orderNumber, at).Scan(
+		&state.OrderNumber,
+		&state.Status,
+		&state.ChangedAt,
+		&state.ChangedBy,
+	)
+	if err != nil {
+		r.Logger.Error("get_order_state_at_failed", "Failed to get order state at time", orderNumber, err)
+		return models.OrderStateAt{}, err
+	}
+
+	return state, nil
+}
+
+func (r *PostgresOrderRepository) GetAllOrderStatesAt(ctx context.Context, at time.Time) ([]models.OrderStateAt, error) {
+	query := `
+		SELECT DISTINCT ON (o.id)
+			o.number,
+			osl.status,
+			osl.changed_at,
+			coalesce(osl.changed_by, '')
+		FROM order_status_log osl
+		JOIN orders o ON osl.order_id = o.id
+		WHERE osl.changed_at <= $1
+		ORDER BY o.id, osl.changed_at DESC, osl.id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, at)
+	if err != nil {
+		r.Logger.Error("get_all_order_states_at_failed", "Failed to get order states at time", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := []models.OrderStateAt{}
+	for rows.Next() {
+		state := models.OrderStateAt{At: at}
+		err := rows.Scan(
+			&state.OrderNumber,
+			&state.Status,
+			&state.ChangedAt,
+			&state.ChangedBy,
+		)
+		if err != nil {
+			r.Logger.Error("scan_order_state_failed", "Failed to scan order state", "", err)
+			return nil, err
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over order states", "", err)
+		return nil, err
+	}
+
+	return states, nil
+}
+
+func (r *PostgresOrderRepository) GetOrderNumberByToken(ctx context.Context, token string) (string, error) {
+	query := `
+		SELECT number
+		FROM orders
+		WHERE tracking_token = $1
+	`
+
+	var orderNumber string
+	if err := r.db.QueryRow(ctx, query, token).Scan(&orderNumber); err != nil {
+		// the token itself is a credential, keep it out of the logs
+		r.Logger.Error("get_order_by_token_failed", "Failed to resolve tracking token", "", err)
+		return "", err
+	}
+
+	return orderNumber, nil
+}
+
+func (r *PostgresOrderRepository) GetOrdersByNumbers(ctx context.Context, orderNumbers []string) ([]models.OrderStatusResponse, error) {
+	query := `
+		SELECT 
+			number, 
+			type,
+			status, 
+			updated_at, 
+			processed_by
+		FROM orders 
+		WHERE number = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, orderNumbers)
+	if err != nil {
+		r.Logger.Error("get_orders_by_numbers_failed", "Failed to get orders by numbers", "", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.OrderStatusResponse
+	for rows.Next() {
+		var order models.OrderStatusResponse
+		err := rows.Scan(
+			&order.OrderNumber,
+			&order.OrderType,
+			&order.CurrentStatus,
+			&order.UpdatedAt,
+			&order.ProcessedBy,
+		)
+		if err != nil {
+			r.Logger.Error("scan_order_failed", "Failed to scan order", "", err)
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over orders", "", err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *PostgresOrderRepository) GetNotificationDeliveries(ctx context.Context, orderNumber string) ([]models.NotificationDelivery, error) {
+	query := `
+		SELECT
+			channel,
+			recipient,
+			order_status,
+			status,
+			attempts,
+			last_error,
+			next_attempt_at,
+			delivered_at,
+			created_at
+		FROM notification_deliveries
+		WHERE order_number = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, orderNumber)
+	if err != nil {
+		r.Logger.Error("get_notification_deliveries_failed", "Failed to get notification deliveries", orderNumber, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.NotificationDelivery{}
+	for rows.Next() {
+		var delivery models.NotificationDelivery
+		err := rows.Scan(
+			&delivery.Channel,
+			&delivery.Recipient,
+			&delivery.OrderStatus,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			r.Logger.Error("scan_notification_delivery_failed", "Failed to scan notification delivery", orderNumber, err)
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over notification delivery rows", orderNumber, err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
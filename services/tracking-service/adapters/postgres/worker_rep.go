@@ -6,6 +6,7 @@ import (
 	"restaurant-system/services/tracking-service/domain/models"
 	"restaurant-system/services/tracking-service/domain/ports"
 	"restaurant-system/services/tracking-service/utils/logger"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -26,6 +27,9 @@ func (r *PostgresWorkerRepository) GetAllWorkersStatus(ctx context.Context) ([]m
 	query := `
 		SELECT 
 			name as worker_name, 
+			type,
+			coalesce(status, ''),
+			heartbeat_interval,
 			orders_processed, 
 			last_seen
 		FROM workers
@@ -44,6 +48,9 @@ func (r *PostgresWorkerRepository) GetAllWorkersStatus(ctx context.Context) ([]m
 		var worker models.WorkerStatus
 		err := rows.Scan(
 			&worker.WorkerName,
+			&worker.Type,
+			&worker.StoredStatus,
+			&worker.HeartbeatIntervalSeconds,
 			&worker.OrdersProcessed,
 			&worker.LastSeen,
 		)
@@ -61,3 +68,125 @@ func (r *PostgresWorkerRepository) GetAllWorkersStatus(ctx context.Context) ([]m
 
 	return workers, nil
 }
+
+func (r *PostgresWorkerRepository) GetWorkerStatus(ctx context.Context, workerName string) (models.WorkerStatus, error) {
+	query := `
+		SELECT 
+			name as worker_name, 
+			type,
+			coalesce(status, ''),
+			heartbeat_interval,
+			orders_processed, 
+			last_seen
+		FROM workers
+		WHERE name = $1
+	`
+
+	var worker models.WorkerStatus
+	err := r.db.QueryRow(ctx, query, workerName).Scan(
+		&worker.WorkerName,
+		&worker.Type,
+		&worker.StoredStatus,
+		&worker.HeartbeatIntervalSeconds,
+		&worker.OrdersProcessed,
+		&worker.LastSeen,
+	)
+	if err != nil {
+		r.Logger.Error("get_worker_failed", "Failed to get worker", workerName, err)
+		return models.WorkerStatus{}, err
+	}
+
+	return worker, nil
+}
+
+func (r *PostgresWorkerRepository) GetWorkerOrders(ctx context.Context, workerName string, statuses []string, limit int) ([]models.WorkerOrder, error) {
+	query := `
+		SELECT 
+			number,
+			type,
+			status,
+			total_amount::float8,
+			updated_at
+		FROM orders
+		WHERE processed_by = $1
+		  AND status = ANY($2)
+		ORDER BY updated_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, workerName, statuses, limit)
+	if err != nil {
+		r.Logger.Error("get_worker_orders_failed", "Failed to get worker orders", workerName, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []models.WorkerOrder{}
+	for rows.Next() {
+		var order models.WorkerOrder
+		err := rows.Scan(
+			&order.OrderNumber,
+			&order.OrderType,
+			&order.Status,
+			&order.TotalAmount,
+			&order.UpdatedAt,
+		)
+		if err != nil {
+			r.Logger.Error("scan_worker_order_failed", "Failed to scan worker order", workerName, err)
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over worker orders", workerName, err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *PostgresWorkerRepository) GetWorkerDailyTotals(ctx context.Context, workerName string, from time.Time) ([]models.DailyTotal, error) {
+	query := `
+		SELECT 
+			to_char(date_trunc('day', finished_at), 'YYYY-MM-DD') as day,
+			count(*),
+			avg(actual_seconds)::float8,
+			count(*) FILTER (WHERE actual_seconds > estimated_seconds)
+		FROM order_cook_metrics
+		WHERE worker_name = $1
+		  AND finished_at >= $2
+		GROUP BY day
+		ORDER BY day DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, workerName, from)
+	if err != nil {
+		r.Logger.Error("get_worker_daily_totals_failed", "Failed to get worker daily totals", workerName, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.DailyTotal{}
+	for rows.Next() {
+		var total models.DailyTotal
+		err := rows.Scan(
+			&total.Date,
+			&total.OrdersCooked,
+			&total.AvgActualSeconds,
+			&total.OverdueCount,
+		)
+		if err != nil {
+			r.Logger.Error("scan_daily_total_failed", "Failed to scan worker daily total", workerName, err)
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("rows_iteration_failed", "Error iterating over worker daily totals", workerName, err)
+		return nil, err
+	}
+
+	return totals, nil
+}
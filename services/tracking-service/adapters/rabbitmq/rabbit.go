@@ -0,0 +1,104 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"restaurant-system/services/tracking-service/config"
+	"restaurant-system/services/tracking-service/utils/logger"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type Client struct {
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+func NewClient(rabbitConfig config.RabbitMQConfig, serviceName string) (*Client, error) {
+	log := logger.New(serviceName)
+
+	conn, err := amqp.Dial(rabbitConfig.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	log.Info("rabbitmq_connected", "Connected to RabbitMQ", "")
+
+	return &Client{conn: conn, channel: ch}, nil
+}
+
+func (c *Client) DeclareExchange(name, exchangeType string) error {
+	err := c.channel.ExchangeDeclare(
+		name,         // name
+		exchangeType, // type: "topic", "fanout", etc.
+		true,         // durable
+		false,        // auto-delete
+		false,        // internal
+		false,        // no-wait
+		nil,          // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeclareExclusiveQueue declares a server-named queue that is removed with the connection
+func (c *Client) DeclareExclusiveQueue() (amqp.Queue, error) {
+	queue, err := c.channel.QueueDeclare(
+		"",    // server-named
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // args
+	)
+	if err != nil {
+		return amqp.Queue{}, fmt.Errorf("failed to declare exclusive queue: %w", err)
+	}
+	return queue, nil
+}
+
+func (c *Client) BindQueue(queueName, exchange, routingKey string) error {
+	err := c.channel.QueueBind(
+		queueName,
+		routingKey,
+		exchange,
+		false, // no-wait
+		nil,   // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to bind queue %s to exchange %s with key %s: %w", queueName, exchange, routingKey, err)
+	}
+	return nil
+}
+
+// ConsumeAutoAck is used for live feeds where a lost event is replaced by the next replay
+func (c *Client) ConsumeAutoAck(queueName, consumer string) (<-chan amqp.Delivery, error) {
+	msgs, err := c.channel.Consume(
+		queueName,
+		consumer, // consumer
+		true,     // auto-ack
+		true,     // exclusive
+		false,    // no-local
+		false,    // no-wait
+		nil,      // args
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume from queue %s: %w", queueName, err)
+	}
+	return msgs, nil
+}
+
+func (c *Client) Close() {
+	if c.channel != nil {
+		c.channel.Close()
+	}
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
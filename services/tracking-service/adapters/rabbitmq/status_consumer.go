@@ -0,0 +1,99 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/services/tracking-service/utils/logger"
+	"restaurant-system/shared/events"
+)
+
+type StatusConsumer struct {
+	client *Client
+	logger *logger.Logger
+}
+
+func NewStatusConsumer(client *Client, serviceName string) ports.StatusEventConsumer {
+	return &StatusConsumer{
+		client: client,
+		logger: logger.New(serviceName),
+	}
+}
+
+func (c *StatusConsumer) ConsumeStatusEvents(ctx context.Context) (<-chan models.StatusEvent, error) {
+	if err := c.client.DeclareExchange("notifications_fanout", "fanout"); err != nil {
+		return nil, err
+	}
+
+	// every tracking instance needs its own copy of the fanout
+	queue, err := c.client.DeclareExclusiveQueue()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.client.BindQueue(queue.Name, "notifications_fanout", ""); err != nil {
+		return nil, err
+	}
+
+	msgs, err := c.client.ConsumeAutoAck(queue.Name, "tracking-status-stream")
+	if err != nil {
+		return nil, err
+	}
+
+	statusEvents := make(chan models.StatusEvent)
+	go func() {
+		defer close(statusEvents)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-msgs:
+				if !ok {
+					return
+				}
+
+				envelope, err := events.Peek(delivery.Body)
+				if err != nil {
+					c.logger.Error("status_event_decode_failed", "Failed to decode status event", "", err)
+					continue
+				}
+				// other fanout events (e.g. low stock) are not order statuses;
+				// status events from publishers without the envelope have no type
+				if envelope.EventType != events.TypeOrderStatusChanged && envelope.EventType != "" {
+					continue
+				}
+
+				var message events.OrderStatusChanged
+				if err := json.Unmarshal(delivery.Body, &message); err != nil {
+					c.logger.Error("status_event_decode_failed", "Failed to decode status event", "", err)
+					continue
+				}
+				if message.NewStatus == "" {
+					continue
+				}
+
+				event := models.StatusEvent{
+					OrderNumber:         message.OrderNumber,
+					OrderType:           message.OrderType,
+					OldStatus:           message.OldStatus,
+					NewStatus:           message.NewStatus,
+					ChangedBy:           message.ChangedBy,
+					Timestamp:           message.Timestamp,
+					EstimatedCompletion: message.EstimatedCompletion,
+				}
+				// older kitchen builds send a zero time when there is no estimate
+				if event.EstimatedCompletion != nil && event.EstimatedCompletion.IsZero() {
+					event.EstimatedCompletion = nil
+				}
+
+				select {
+				case statusEvents <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return statusEvents, nil
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// GetThroughput serves orders and revenue per ?bucket=hour (default) or day
+func (h *WebHandler) GetThroughput(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	from, to, err := h.parseTimeWindow(r)
+	if err != nil {
+		log.Printf("Invalid time window: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		bucket = "hour"
+	}
+
+	report, err := h.AnalyticsService.GetThroughput(r.Context(), from, to, bucket)
+	if err != nil {
+		log.Printf("Error getting throughput: %v", err)
+		if strings.Contains(err.Error(), "validation") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *WebHandler) GetStatusDurations(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	from, to, err := h.parseTimeWindow(r)
+	if err != nil {
+		log.Printf("Invalid time window: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	durations, err := h.AnalyticsService.GetStatusDurations(r.Context(), from, to)
+	if err != nil {
+		log.Printf("Error getting status durations: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(durations)
+}
+
+func (h *WebHandler) GetBreakdown(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	from, to, err := h.parseTimeWindow(r)
+	if err != nil {
+		log.Printf("Invalid time window: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	breakdown, err := h.AnalyticsService.GetBreakdown(r.Context(), from, to)
+	if err != nil {
+		log.Printf("Error getting order breakdown: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(breakdown)
+}
+
+func (h *WebHandler) GetLateOrders(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	from, to, err := h.parseTimeWindow(r)
+	if err != nil {
+		log.Printf("Invalid time window: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.AnalyticsService.GetLateOrders(r.Context(), from, to)
+	if err != nil {
+		log.Printf("Error getting late orders: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
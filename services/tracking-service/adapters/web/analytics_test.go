@@ -0,0 +1,160 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/services/tracking-service/domain/service"
+	"restaurant-system/shared/clock"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	h := NewWebHandler(nil, nil, nil, clock.NewManual(now))
+
+	tests := []struct {
+		name     string
+		query    string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  string
+	}{
+		{"defaults to the last day", "", now.Add(-defaultTimeWindow), now, ""},
+		{"from only", "from=2026-10-19T06:00:00Z", now.Add(-6 * time.Hour), now, ""},
+		{"to only", "to=2026-10-18T12:00:00Z", now.Add(-48 * time.Hour), now.Add(-24 * time.Hour), ""},
+		{"both", "from=2026-10-19T10:00:00Z&to=2026-10-19T11:00:00Z", now.Add(-2 * time.Hour), now.Add(-time.Hour), ""},
+		{"bad to", "to=yesterday", time.Time{}, time.Time{}, "invalid 'to' parameter"},
+		{"bad from", "from=2026-10-19", time.Time{}, time.Time{}, "invalid 'from' parameter"},
+		{"empty window", "from=2026-10-19T11:00:00Z&to=2026-10-19T11:00:00Z", time.Time{}, time.Time{}, "'from' must be before 'to'"},
+		{"reversed window", "from=2026-10-19T11:00:00Z&to=2026-10-19T10:00:00Z", time.Time{}, time.Time{}, "'from' must be before 'to'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/analytics/throughput?"+tt.query, nil)
+			from, to, err := h.parseTimeWindow(r)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTimeWindow: %v", err)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("window = [%v, %v), want [%v, %v)", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+type fakeAnalyticsRepo struct {
+	ports.AnalyticsRepository
+	err error
+}
+
+func (r fakeAnalyticsRepo) GetOrdersByPeriod(ctx context.Context, from, to time.Time, bucket string) ([]models.PeriodStats, error) {
+	return nil, r.err
+}
+
+func (r fakeAnalyticsRepo) GetLateOrders(ctx context.Context, from, to, now time.Time, timeScale float64) ([]models.LateOrders, error) {
+	return nil, r.err
+}
+
+func TestAnalyticsHandlersStatus(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		handler func(h *WebHandler) http.HandlerFunc
+		query   string
+		repoErr error
+		want    int
+	}{
+		{"throughput by hour", func(h *WebHandler) http.HandlerFunc { return h.GetThroughput }, "", nil, http.StatusOK},
+		{"throughput by day", func(h *WebHandler) http.HandlerFunc { return h.GetThroughput }, "bucket=day", nil, http.StatusOK},
+		{"throughput unknown bucket", func(h *WebHandler) http.HandlerFunc { return h.GetThroughput }, "bucket=week", nil, http.StatusBadRequest},
+		{"throughput bad window", func(h *WebHandler) http.HandlerFunc { return h.GetThroughput }, "from=soon", nil, http.StatusBadRequest},
+		{"throughput database down", func(h *WebHandler) http.HandlerFunc { return h.GetThroughput }, "", errors.New("connection refused"), http.StatusInternalServerError},
+		{"late orders", func(h *WebHandler) http.HandlerFunc { return h.GetLateOrders }, "", nil, http.StatusOK},
+		{"late orders bad window", func(h *WebHandler) http.HandlerFunc { return h.GetLateOrders }, "to=later", nil, http.StatusBadRequest},
+		{"late orders database down", func(h *WebHandler) http.HandlerFunc { return h.GetLateOrders }, "", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewManual(now)
+			h := NewWebHandler(nil, nil, service.NewAnalyticsService(fakeAnalyticsRepo{err: tt.repoErr}, clk), clk)
+
+			rec := httptest.NewRecorder()
+			tt.handler(h)(rec, httptest.NewRequest(http.MethodGet, "/analytics?"+tt.query, nil))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+// stateOrderRepo knows the status of ORD_1 at any moment and records the moment asked for
+type stateOrderRepo struct {
+	ports.OrderRepository
+	gotAt time.Time
+}
+
+func (r *stateOrderRepo) GetOrderStateAt(ctx context.Context, orderNumber string, at time.Time) (models.OrderStateAt, error) {
+	r.gotAt = at
+	if orderNumber != "ORD_1" {
+		return models.OrderStateAt{}, errors.New("no rows in result set")
+	}
+	return models.OrderStateAt{OrderNumber: orderNumber, At: at, Status: "cooking"}, nil
+}
+
+func (r *stateOrderRepo) GetAllOrderStatesAt(ctx context.Context, at time.Time) ([]models.OrderStateAt, error) {
+	r.gotAt = at
+	return []models.OrderStateAt{{OrderNumber: "ORD_1", At: at, Status: "cooking"}}, nil
+}
+
+func TestOrderStatusAt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	past := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		handler func(h *WebHandler) http.HandlerFunc
+		target  string
+		want    int
+		wantAt  time.Time
+	}{
+		{"one order in the past", func(h *WebHandler) http.HandlerFunc { return h.GetOrderStatus }, "/orders/ORD_1/status?at=2026-10-19T11:00:00Z", http.StatusOK, past},
+		{"one order bad at", func(h *WebHandler) http.HandlerFunc { return h.GetOrderStatus }, "/orders/ORD_1/status?at=11:00", http.StatusBadRequest, time.Time{}},
+		{"one order without a status then", func(h *WebHandler) http.HandlerFunc { return h.GetOrderStatus }, "/orders/ORD_2/status?at=2026-10-19T11:00:00Z", http.StatusNotFound, past},
+		{"all orders in the past", func(h *WebHandler) http.HandlerFunc { return h.GetAllOrdersStatus }, "/orders/status?at=2026-10-19T11:00:00Z", http.StatusOK, past},
+		{"all orders default to now", func(h *WebHandler) http.HandlerFunc { return h.GetAllOrdersStatus }, "/orders/status", http.StatusOK, now},
+		{"all orders bad at", func(h *WebHandler) http.HandlerFunc { return h.GetAllOrdersStatus }, "/orders/status?at=noon", http.StatusBadRequest, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewManual(now)
+			repo := &stateOrderRepo{}
+			h := NewWebHandler(service.NewTrackingService(repo, nil, nil, clk), nil, nil, clk)
+
+			rec := httptest.NewRecorder()
+			tt.handler(h)(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+			if !repo.gotAt.Equal(tt.wantAt) {
+				t.Errorf("repository asked for %v, want %v", repo.gotAt, tt.wantAt)
+			}
+		})
+	}
+}
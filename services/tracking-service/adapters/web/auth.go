@@ -0,0 +1,33 @@
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// staffCookie carries the staff token for EventSource and WebSocket clients,
+// which cannot set headers; tokens in the URL would end up in access logs
+const staffCookie = "staff_token"
+
+// requireStaff checks the X-Staff-Token header or the staff_token cookie.
+// Without a configured token every staff request is refused.
+func requireStaff(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.Error(w, "Staff API is disabled: TRACKING_STAFF_TOKEN is not set", http.StatusServiceUnavailable)
+			return
+		}
+
+		provided := r.Header.Get("X-Staff-Token")
+		if provided == "" {
+			if cookie, err := r.Cookie(staffCookie); err == nil {
+				provided = cookie.Value
+			}
+		}
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
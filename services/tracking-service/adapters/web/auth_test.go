@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireStaff(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		cookie     string
+		query      string
+		wantStatus int
+	}{
+		{"no token configured", "", "secret", "", "", http.StatusServiceUnavailable},
+		{"header", "secret", "secret", "", "", http.StatusOK},
+		{"cookie", "secret", "", "secret", "", http.StatusOK},
+		{"wrong header", "secret", "guess", "", "", http.StatusUnauthorized},
+		{"missing", "secret", "", "", "", http.StatusUnauthorized},
+		{"query parameter is not accepted", "secret", "", "", "secret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders/events", nil)
+			if tt.header != "" {
+				r.Header.Set("X-Staff-Token", tt.header)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: staffCookie, Value: tt.cookie})
+			}
+			if tt.query != "" {
+				r.URL.RawQuery = "staff_token=" + tt.query
+			}
+			w := httptest.NewRecorder()
+			requireStaff(tt.token, ok)(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
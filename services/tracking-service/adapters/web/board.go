@@ -0,0 +1,25 @@
+package web
+
+import (
+	_ "embed"
+	"log"
+	"net/http"
+)
+
+//go:embed static/board.html
+var boardPage []byte
+
+// Board serves the self-contained status board page for in-store screens
+func (h *WebHandler) Board(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(boardPage)
+}
+
+// BoardEvents feeds the board: every active order, without worker names
+func (h *WebHandler) BoardEvents(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	h.serveSSE(w, r, "", true)
+}
@@ -0,0 +1,36 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// TrackOrder is the customer view of an order, keyed by the tracking token from order creation
+func (h *WebHandler) TrackOrder(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s /track/{token}", r.Method)
+
+	status, err := h.TrackingService.GetPublicOrderStatus(r.Context(), r.PathValue("token"))
+	if err != nil {
+		log.Printf("Error getting tracked order: %v", err)
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// TrackOrderEvents streams the tracked order's status changes as Server-Sent Events
+func (h *WebHandler) TrackOrderEvents(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s /track/{token}/events", r.Method)
+
+	orderNumber, err := h.TrackingService.ResolveTrackingToken(r.Context(), r.PathValue("token"))
+	if err != nil {
+		log.Printf("Error resolving tracking token: %v", err)
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	h.serveSSE(w, r, orderNumber, true)
+}
@@ -2,12 +2,33 @@ package web
 
 import "net/http"
 
-func NewRouter(handler *WebHandler) http.Handler {
+// NewRouter serves /track/{token} and the status board publicly; everything keyed by order number or worker is staff-only
+func NewRouter(handler *WebHandler, staffToken string) http.Handler {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("GET /orders/{order_number}/status", handler.GetOrderStatus)
-	mux.HandleFunc("GET /orders/{order_number}/history", handler.GetOrderHistory)
-	mux.HandleFunc("GET /workers/status", handler.GetWorkersStatus)
+	mux.HandleFunc("GET /track/{token}", handler.TrackOrder)
+	mux.HandleFunc("GET /track/{token}/events", handler.TrackOrderEvents)
+	mux.HandleFunc("GET /board", handler.Board)
+	mux.HandleFunc("GET /board/events", handler.BoardEvents)
+
+	mux.HandleFunc("POST /orders/status:batch", requireStaff(staffToken, handler.GetOrdersStatusBatch))
+	mux.HandleFunc("GET /orders/status", requireStaff(staffToken, handler.GetAllOrdersStatus))
+	mux.HandleFunc("GET /orders/{order_number}/status", requireStaff(staffToken, handler.GetOrderStatus))
+	mux.HandleFunc("GET /orders/{order_number}/history", requireStaff(staffToken, handler.GetOrderHistory))
+	mux.HandleFunc("GET /orders/{order_number}/position", requireStaff(staffToken, handler.GetOrderPosition))
+	mux.HandleFunc("GET /orders/{order_number}/notifications", requireStaff(staffToken, handler.GetOrderNotifications))
+	mux.HandleFunc("GET /orders/events", requireStaff(staffToken, handler.StreamOrderEvents))
+	mux.HandleFunc("GET /orders/ws", requireStaff(staffToken, handler.StreamOrderEventsWS))
+	mux.HandleFunc("GET /orders/{order_number}/events", requireStaff(staffToken, handler.StreamOrderEvents))
+	mux.HandleFunc("GET /orders/{order_number}/ws", requireStaff(staffToken, handler.StreamOrderEventsWS))
+	mux.HandleFunc("GET /workers/status", requireStaff(staffToken, handler.GetWorkersStatus))
+	mux.HandleFunc("GET /workers/metrics", requireStaff(staffToken, handler.GetWorkersMetrics))
+	mux.HandleFunc("GET /workers/{worker_name}", requireStaff(staffToken, handler.GetWorkerDetail))
+	mux.HandleFunc("GET /workers/{worker_name}/metrics", requireStaff(staffToken, handler.GetWorkersMetrics))
+	mux.HandleFunc("GET /analytics/throughput", requireStaff(staffToken, handler.GetThroughput))
+	mux.HandleFunc("GET /analytics/status-durations", requireStaff(staffToken, handler.GetStatusDurations))
+	mux.HandleFunc("GET /analytics/breakdown", requireStaff(staffToken, handler.GetBreakdown))
+	mux.HandleFunc("GET /analytics/late", requireStaff(staffToken, handler.GetLateOrders))
 
 	return mux
 }
@@ -0,0 +1,153 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"restaurant-system/services/tracking-service/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// keeps idle SSE connections alive through proxies
+const sseKeepAlive = 15 * time.Second
+
+// StreamOrderEvents serves Server-Sent Events for one order ({order_number}) or for all orders
+func (h *WebHandler) StreamOrderEvents(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	h.serveSSE(w, r, r.PathValue("order_number"), false)
+}
+
+// serveSSE streams one order, or every order for an empty orderNumber.
+// Public streams drop the worker name from each event.
+func (h *WebHandler) serveSSE(w http.ResponseWriter, r *http.Request, orderNumber string, public bool) {
+	replay, sub, err := h.StreamService.Subscribe(r.Context(), orderNumber)
+	if err != nil {
+		subscribeFailed(w, err)
+		return
+	}
+	defer h.StreamService.Unsubscribe(sub)
+
+	// the stream outlives the server's WriteTimeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range replay {
+		if err := writeSSE(w, event, public); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			if err := writeSSE(w, event, public); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// subscribeFailed answers a failed Subscribe; only an unknown order is a 404
+func subscribeFailed(w http.ResponseWriter, err error) {
+	log.Printf("Error subscribing to order events: %v", err)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// StreamOrderEventsWS is the WebSocket variant of StreamOrderEvents
+func (h *WebHandler) StreamOrderEventsWS(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	orderNumber := r.PathValue("order_number")
+	replay, sub, err := h.StreamService.Subscribe(r.Context(), orderNumber)
+	if err != nil {
+		subscribeFailed(w, err)
+		return
+	}
+	defer h.StreamService.Unsubscribe(sub)
+
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		http.Error(w, "WebSocket upgrade required", http.StatusBadRequest)
+		return
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		conn.readLoop()
+		close(closed)
+	}()
+
+	for _, event := range replay {
+		if err := writeWS(conn, event); err != nil {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			if err := writeWS(conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event models.StatusEvent, public bool) error {
+	if public {
+		event.ChangedBy = ""
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+	return err
+}
+
+func writeWS(conn *wsConn, event models.StatusEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return conn.WriteText(data)
+}
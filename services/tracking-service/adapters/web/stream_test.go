@@ -0,0 +1,33 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestSubscribeFailedStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unknown order", pgx.ErrNoRows, http.StatusNotFound},
+		{"wrapped unknown order", fmt.Errorf("get order: %w", pgx.ErrNoRows), http.StatusNotFound},
+		{"database down", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			subscribeFailed(rec, tt.err)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
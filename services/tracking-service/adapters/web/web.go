@@ -2,18 +2,34 @@ package web
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
 	"restaurant-system/services/tracking-service/domain/service"
 	"strings"
+	"time"
 )
 
+// default window for time-ranged queries when "from" is omitted
+const defaultTimeWindow = 24 * time.Hour
+
 type WebHandler struct {
-	TrackingService *service.TrackingService
+	TrackingService  *service.TrackingService
+	StreamService    *service.StreamService
+	AnalyticsService *service.AnalyticsService
+	// Clock supplies "now" for query defaults
+	Clock ports.Clock
 }
 
-func NewWebHandler(trackingService *service.TrackingService) *WebHandler {
-	return &WebHandler{TrackingService: trackingService}
+func NewWebHandler(trackingService *service.TrackingService, streamService *service.StreamService, analyticsService *service.AnalyticsService, clock ports.Clock) *WebHandler {
+	return &WebHandler{
+		TrackingService:  trackingService,
+		StreamService:    streamService,
+		AnalyticsService: analyticsService,
+		Clock:            clock,
+	}
 }
 
 func (h *WebHandler) GetOrderStatus(w http.ResponseWriter, r *http.Request) {
@@ -27,6 +43,26 @@ func (h *WebHandler) GetOrderStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ?at= answers "what status was it in at that moment" from the status log
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "invalid 'at' parameter, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+
+		state, err := h.TrackingService.GetOrderStatusAt(r.Context(), orderNumber, at)
+		if err != nil {
+			log.Printf("Error getting order status at %s: %v", raw, err)
+			http.Error(w, "Order not found at that time", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state)
+		return
+	}
+
 	status, err := h.TrackingService.GetOrderStatus(r.Context(), orderNumber)
 	if err != nil {
 		log.Printf("Error getting order status: %v", err)
@@ -38,6 +74,56 @@ func (h *WebHandler) GetOrderStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(status)
 }
 
+// GetOrdersStatusBatch answers {"order_numbers": [...]} with one result per number, found or not
+func (h *WebHandler) GetOrdersStatusBatch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	var req models.BatchStatusRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.TrackingService.GetOrderStatuses(r.Context(), req.OrderNumbers)
+	if err != nil {
+		log.Printf("Error getting batch status: %v", err)
+		if strings.Contains(err.Error(), "validation") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// GetAllOrdersStatus returns the state of every order at ?at= (default now), for end-of-shift audits
+func (h *WebHandler) GetAllOrdersStatus(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	at := h.Clock.Now()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "invalid 'at' parameter, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+		at = parsed
+	}
+
+	states, err := h.TrackingService.GetAllOrderStatusesAt(r.Context(), at)
+	if err != nil {
+		log.Printf("Error getting order states: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(states)
+}
+
 func (h *WebHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
 
@@ -60,6 +146,22 @@ func (h *WebHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(history)
 }
 
+func (h *WebHandler) GetOrderNotifications(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	orderNumber := r.PathValue("order_number")
+
+	notifications, err := h.TrackingService.GetOrderNotifications(r.Context(), orderNumber)
+	if err != nil {
+		log.Printf("Error getting order notifications: %v", err)
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(notifications)
+}
+
 func (h *WebHandler) GetWorkersStatus(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
 
@@ -74,6 +176,89 @@ func (h *WebHandler) GetWorkersStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(workers)
 }
 
+func (h *WebHandler) GetWorkerDetail(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	workerName := r.PathValue("worker_name")
+
+	detail, err := h.TrackingService.GetWorkerDetail(r.Context(), workerName)
+	if err != nil {
+		log.Printf("Error getting worker detail: %v", err)
+		http.Error(w, "Worker not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+func (h *WebHandler) GetWorkersMetrics(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	from, to, err := h.parseTimeWindow(r)
+	if err != nil {
+		log.Printf("Invalid time window: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// пустое имя — метрики по всем воркерам
+	workerName := r.PathValue("worker_name")
+
+	metrics, err := h.TrackingService.GetWorkerMetrics(r.Context(), workerName, from, to)
+	if err != nil {
+		log.Printf("Error getting worker metrics: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(metrics)
+}
+
+func (h *WebHandler) GetOrderPosition(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Request received: %s %s", r.Method, r.URL.Path)
+
+	orderNumber := r.PathValue("order_number")
+
+	position, err := h.TrackingService.GetQueuePosition(r.Context(), orderNumber)
+	if err != nil {
+		log.Printf("Error getting queue position: %v", err)
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(position)
+}
+
+// parseTimeWindow reads optional RFC3339 "from" and "to" query parameters.
+// "to" defaults to now and "from" to defaultTimeWindow before "to".
+func (h *WebHandler) parseTimeWindow(r *http.Request) (time.Time, time.Time, error) {
+	to := h.Clock.Now()
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'to' parameter, expected RFC3339")
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultTimeWindow)
+	if raw := r.URL.Query().Get("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'from' parameter, expected RFC3339")
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("'from' must be before 'to'")
+	}
+	return from, to, nil
+}
+
 // Helper function to extract order number from URL path
 func extractOrderNumber(path, prefix, suffix string) string {
 	if !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, suffix) {
@@ -0,0 +1,240 @@
+package web
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Minimal server side of RFC 6455: text frames out, control frames in.
+// Enough for a read-only status feed without pulling in a dependency.
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// Close status codes (RFC 6455 section 7.4.1)
+const (
+	wsCloseNormal        = 1000
+	wsCloseProtocolError = 1002
+	wsCloseTooBig        = 1009
+)
+
+// client messages are ignored, this only bounds what we are willing to skip over
+const wsMaxClientFrame = 64 << 10
+
+// control frames carry at most 125 bytes and are never fragmented
+const wsMaxControlPayload = 125
+
+// wsProtocolError is a client frame that breaks RFC 6455; the connection is
+// closed with code
+type wsProtocolError struct {
+	code   uint16
+	reason string
+}
+
+func (e *wsProtocolError) Error() string { return e.reason }
+
+type wsConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	mu   sync.Mutex
+	// closeSent — a close frame has gone out, nothing may follow it
+	closeSent bool
+}
+
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		return nil, fmt.Errorf("not a websocket handshake")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, fmt.Errorf("unsupported websocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, fmt.Errorf("missing Sec-WebSocket-Key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, fmt.Errorf("connection does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	// the server's read/write timeouts no longer apply after hijacking
+	conn.SetDeadline(time.Time{})
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, rw: rw}, nil
+}
+
+func (c *wsConn) WriteText(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+func (c *wsConn) Close() error {
+	_ = c.writeClose(wsCloseNormal)
+	return c.conn.Close()
+}
+
+// writeClose sends a close frame with a status code, once
+func (c *wsConn) writeClose(code uint16) error {
+	return c.writeFrame(wsOpClose, binary.BigEndian.AppendUint16(nil, code))
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closeSent {
+		return net.ErrClosed
+	}
+	if opcode == wsOpClose {
+		c.closeSent = true
+	}
+
+	header := []byte{0x80 | opcode} // FIN + opcode, server frames are never masked
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// readLoop answers pings and returns when the client closes, breaks the
+// protocol or the connection drops. Data frames, fragmented or not, are skipped.
+func (c *wsConn) readLoop() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			var protocolErr *wsProtocolError
+			if errors.As(err, &protocolErr) {
+				_ = c.writeClose(protocolErr.code)
+			}
+			return
+		}
+		switch opcode {
+		case wsOpClose:
+			// echo the client's status code, as RFC 6455 asks
+			code := uint16(wsCloseNormal)
+			if len(payload) >= 2 {
+				code = binary.BigEndian.Uint16(payload)
+			}
+			_ = c.writeClose(code)
+			return
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+		return 0, nil, err
+	}
+	fin := head[0]&0x80 != 0
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	if head[0]&0x70 != 0 {
+		return 0, nil, &wsProtocolError{wsCloseProtocolError, "reserved bits set without an extension"}
+	}
+	if !masked {
+		return 0, nil, &wsProtocolError{wsCloseProtocolError, "client frames must be masked"}
+	}
+	if opcode >= wsOpClose && (!fin || length > wsMaxControlPayload) {
+		return 0, nil, &wsProtocolError{wsCloseProtocolError, "invalid control frame"}
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > wsMaxClientFrame {
+		return 0, nil, &wsProtocolError{wsCloseTooBig, "websocket frame too large"}
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+		return 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
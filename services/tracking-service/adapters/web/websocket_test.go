@@ -0,0 +1,269 @@
+package web
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6455 section 1.3 sample handshake
+const (
+	testWSKey    = "dGhlIHNhbXBsZSBub25jZQ=="
+	testWSAccept = "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="
+)
+
+// newWSServer upgrades every request, sends "hello" and then serves readLoop
+func newWSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgradeWebSocket(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer conn.Close()
+		if err := conn.WriteText([]byte("hello")); err != nil {
+			return
+		}
+		conn.readLoop()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type wsTestClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// dialWS performs the handshake and checks the 101 response
+func dialWS(t *testing.T, server *httptest.Server) *wsTestClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	request := "GET /ws HTTP/1.1\r\n" +
+		"Host: " + server.Listener.Addr().String() + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: keep-alive, Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + testWSKey + "\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatalf("read handshake response: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want 101", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != testWSAccept {
+		t.Fatalf("Sec-WebSocket-Accept = %q, want %q", got, testWSAccept)
+	}
+	return &wsTestClient{conn: conn, r: r}
+}
+
+// writeFrame sends a masked client frame
+func (c *wsTestClient) writeFrame(t *testing.T, fin bool, opcode byte, payload []byte) {
+	t.Helper()
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	frame := []byte{first}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	mask := [4]byte{0x37, 0xfa, 0x21, 0x3d}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("write frame: %v", err)
+	}
+}
+
+// readFrame reads one server frame and checks it is final and unmasked
+func (c *wsTestClient) readFrame(t *testing.T) (byte, []byte) {
+	t.Helper()
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		t.Fatalf("read frame header: %v", err)
+	}
+	if head[0]&0x80 == 0 {
+		t.Fatal("server frame is not final")
+	}
+	if head[1]&0x80 != 0 {
+		t.Fatal("server frame is masked")
+	}
+	length := int(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		io.ReadFull(c.r, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		io.ReadFull(c.r, ext[:])
+		length = int(binary.BigEndian.Uint64(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		t.Fatalf("read frame payload: %v", err)
+	}
+	return head[0] & 0x0F, payload
+}
+
+func (c *wsTestClient) expectText(t *testing.T, want string) {
+	t.Helper()
+	opcode, payload := c.readFrame(t)
+	if opcode != wsOpText || string(payload) != want {
+		t.Fatalf("got frame %#x %q, want text %q", opcode, payload, want)
+	}
+}
+
+func (c *wsTestClient) expectClose(t *testing.T, code uint16) {
+	t.Helper()
+	opcode, payload := c.readFrame(t)
+	if opcode != wsOpClose {
+		t.Fatalf("got frame %#x, want close", opcode)
+	}
+	if len(payload) < 2 || binary.BigEndian.Uint16(payload) != code {
+		t.Fatalf("close payload = %v, want code %d", payload, code)
+	}
+	// nothing follows the close frame
+	if _, err := c.r.ReadByte(); err != io.EOF {
+		t.Fatalf("after close: err = %v, want EOF", err)
+	}
+}
+
+func closePayload(code uint16) []byte {
+	return binary.BigEndian.AppendUint16(nil, code)
+}
+
+func TestWebSocketHandshake(t *testing.T) {
+	client := dialWS(t, newWSServer(t))
+	client.expectText(t, "hello")
+}
+
+func TestWebSocketHandshakeRejected(t *testing.T) {
+	server := newWSServer(t)
+	tests := []struct {
+		name   string
+		header map[string]string
+	}{
+		{"no upgrade", map[string]string{"Sec-WebSocket-Version": "13", "Sec-WebSocket-Key": testWSKey}},
+		{"old version", map[string]string{"Connection": "Upgrade", "Upgrade": "websocket", "Sec-WebSocket-Version": "8", "Sec-WebSocket-Key": testWSKey}},
+		{"no key", map[string]string{"Connection": "Upgrade", "Upgrade": "websocket", "Sec-WebSocket-Version": "13"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestWebSocketMaskedPing(t *testing.T) {
+	client := dialWS(t, newWSServer(t))
+	client.expectText(t, "hello")
+
+	client.writeFrame(t, true, wsOpPing, []byte("are you there"))
+	opcode, payload := client.readFrame(t)
+	if opcode != wsOpPong || string(payload) != "are you there" {
+		t.Fatalf("got frame %#x %q, want pong with the ping payload", opcode, payload)
+	}
+}
+
+func TestWebSocketFragmentedMessageIsSkipped(t *testing.T) {
+	client := dialWS(t, newWSServer(t))
+	client.expectText(t, "hello")
+
+	// a ping may arrive between the fragments of a message
+	client.writeFrame(t, false, wsOpText, []byte("first "))
+	client.writeFrame(t, true, wsOpPing, []byte("mid"))
+	client.writeFrame(t, false, wsOpContinuation, []byte("second "))
+	client.writeFrame(t, true, wsOpContinuation, []byte(strings.Repeat("x", 300)))
+
+	opcode, payload := client.readFrame(t)
+	if opcode != wsOpPong || string(payload) != "mid" {
+		t.Fatalf("got frame %#x %q, want pong \"mid\"", opcode, payload)
+	}
+
+	// the connection is still usable after the message
+	client.writeFrame(t, true, wsOpClose, closePayload(wsCloseNormal))
+	client.expectClose(t, wsCloseNormal)
+}
+
+func TestWebSocketOversizedFrame(t *testing.T) {
+	client := dialWS(t, newWSServer(t))
+	client.expectText(t, "hello")
+
+	// only the header: the server must refuse before reading the payload
+	header := []byte{0x80 | wsOpText, 0x80 | 127}
+	header = binary.BigEndian.AppendUint64(header, wsMaxClientFrame+1)
+	if _, err := client.conn.Write(header); err != nil {
+		t.Fatalf("write header: %v", err)
+	}
+	client.expectClose(t, wsCloseTooBig)
+}
+
+func TestWebSocketProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []byte
+	}{
+		{"unmasked frame", []byte{0x80 | wsOpText, 2, 'h', 'i'}},
+		{"fragmented ping", []byte{wsOpPing, 0x80, 0, 0, 0, 0}},
+		{"reserved bit", []byte{0x80 | 0x40 | wsOpText, 0x80, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dialWS(t, newWSServer(t))
+			client.expectText(t, "hello")
+			if _, err := client.conn.Write(tt.frame); err != nil {
+				t.Fatalf("write frame: %v", err)
+			}
+			client.expectClose(t, wsCloseProtocolError)
+		})
+	}
+}
+
+func TestWebSocketClientClose(t *testing.T) {
+	client := dialWS(t, newWSServer(t))
+	client.expectText(t, "hello")
+
+	client.writeFrame(t, true, wsOpClose, closePayload(1001))
+	client.expectClose(t, 1001)
+}
@@ -2,18 +2,23 @@ package trackingservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"restaurant-system/services/tracking-service/adapters/postgres"
+	"restaurant-system/services/tracking-service/adapters/rabbitmq"
 	"restaurant-system/services/tracking-service/adapters/web"
 	"restaurant-system/services/tracking-service/config"
 	"restaurant-system/services/tracking-service/domain/service"
 	"restaurant-system/services/tracking-service/utils/logger"
+	"restaurant-system/shared/clock"
 	"time"
 )
 
 type Config struct {
 	Port int
+	// TimeScale must match the kitchen workers' scale for liveness checks to agree
+	TimeScale float64
 }
 
 func Start(ctx context.Context, cfg Config) error {
@@ -38,16 +43,42 @@ func Start(ctx context.Context, cfg Config) error {
 
 	logger.Info("db_connected", "Connected to PostgreSQL database", "")
 
+	// Connect to RabbitMQ for live status events
+	rabbitClient, err := rabbitmq.NewClient(appConfig.RabbitMQ, serviceName)
+	if err != nil {
+		logger.Error("failed_to_connect_rabbitmq", "Failed to connect to RabbitMQ", "", err)
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	defer rabbitClient.Close()
+
 	// Initialize repositories
 	orderRepo := postgres.NewPostgresOrderRepository(dbPool, serviceName)
 	workerRepo := postgres.NewPostgresWorkerRepository(dbPool, serviceName)
+	metricsRepo := postgres.NewPostgresMetricsRepository(dbPool, serviceName)
+	analyticsRepo := postgres.NewPostgresAnalyticsRepository(dbPool, serviceName)
 
 	// Initialize tracking service
-	trackingService := service.NewTrackingService(orderRepo, workerRepo)
+	clk := clock.New(cfg.TimeScale)
+	trackingService := service.NewTrackingService(orderRepo, workerRepo, metricsRepo, clk)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, clk)
+
+	// Initialize status stream
+	streamCtx, stopStream := context.WithCancel(ctx)
+	defer stopStream()
+
+	statusConsumer := rabbitmq.NewStatusConsumer(rabbitClient, serviceName)
+	streamService := service.NewStreamService(orderRepo, statusConsumer, trackingService)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- streamService.Run(streamCtx)
+	}()
 
 	// Initialize web handler
-	webHandler := web.NewWebHandler(trackingService)
-	router := web.NewRouter(webHandler)
+	webHandler := web.NewWebHandler(trackingService, streamService, analyticsService, clk)
+	if appConfig.StaffToken == "" {
+		logger.Info("staff_disabled", "TRACKING_STAFF_TOKEN is not set, staff routes are disabled", "")
+	}
+	router := web.NewRouter(webHandler, appConfig.StaffToken)
 
 	// Start HTTP server
 	port := cfg.Port
@@ -65,11 +96,46 @@ func Start(ctx context.Context, cfg Config) error {
 
 	logger.Info("service_started", fmt.Sprintf("Tracking service started on port %d", port), "")
 
-	// Start the server
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	// Start server in goroutine
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
 		logger.Error("server_start_failed", "Failed to start server", "", err)
 		return fmt.Errorf("failed to start server: %w", err)
+	case err := <-streamErr:
+		// the stream only ends by itself when the RabbitMQ channel is lost
+		if err == nil && ctx.Err() == nil {
+			err = errors.New("status events channel closed")
+		}
+		if err != nil {
+			logger.Error("status_stream_failed", "Status event stream stopped", "", err)
+			stopStream()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+			return fmt.Errorf("status event stream failed: %w", err)
+		}
+		logger.Info("shutdown_requested", "Shutdown requested via context", "")
+	case <-ctx.Done():
+		logger.Info("shutdown_requested", "Shutdown requested via context", "")
+	}
+
+	// Closing the stream ends open SSE/WebSocket subscriptions so Shutdown does not wait on them
+	stopStream()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
 	}
 
+	logger.Info("service_stopped", "Tracking service stopped gracefully", "")
 	return nil
 }
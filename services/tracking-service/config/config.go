@@ -24,6 +24,8 @@ type RabbitMQConfig struct {
 type Config struct {
 	Database DatabaseConfig
 	RabbitMQ RabbitMQConfig
+	// StaffToken protects every route except the public /track/{token} ones; without it they answer 503
+	StaffToken string
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,6 +43,7 @@ func LoadConfig() (*Config, error) {
 			User:     getEnv("RABBITMQ_USER", "guest"),
 			Password: getEnv("RABBITMQ_PASSWORD", "guest"),
 		},
+		StaffToken: getEnv("TRACKING_STAFF_TOKEN", ""),
 	}
 
 	return config, nil
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// PeriodStats is order volume and revenue for one bucket (hour or day)
+type PeriodStats struct {
+	PeriodStart time.Time `json:"period_start"`
+	Orders      int       `json:"orders"`
+	Revenue     float64   `json:"revenue"`
+}
+
+type ThroughputReport struct {
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	Bucket        string        `json:"bucket"`
+	TotalOrders   int           `json:"total_orders"`
+	TotalRevenue  float64       `json:"total_revenue"`
+	OrdersPerHour float64       `json:"orders_per_hour"`
+	Periods       []PeriodStats `json:"periods"`
+}
+
+// StatusDuration is how long orders stayed in a status before the next change
+type StatusDuration struct {
+	Status     string  `json:"status"`
+	Samples    int     `json:"samples"`
+	AvgSeconds float64 `json:"avg_seconds"`
+	P95Seconds float64 `json:"p95_seconds"`
+}
+
+type BreakdownEntry struct {
+	OrderType string  `json:"order_type"`
+	Priority  int     `json:"priority"`
+	Orders    int     `json:"orders"`
+	Cancelled int     `json:"cancelled"`
+	Revenue   float64 `json:"revenue"`
+	// AvgSecondsToReady covers orders that reached ready; nil when none did
+	AvgSecondsToReady *float64 `json:"avg_seconds_to_ready,omitempty"`
+}
+
+// LateOrders counts orders that missed the kitchen deadline (priority-based max wait plus cook time)
+type LateOrders struct {
+	OrderType string `json:"order_type"`
+	Orders    int    `json:"orders"`
+	// Late were ready after the deadline; LateOpen are past it and still not ready
+	Late     int `json:"late"`
+	LateOpen int `json:"late_open"`
+}
+
+type LateReport struct {
+	From      time.Time    `json:"from"`
+	To        time.Time    `json:"to"`
+	Orders    int          `json:"orders"`
+	Late      int          `json:"late"`
+	LateOpen  int          `json:"late_open"`
+	LateRatio float64      `json:"late_ratio"`
+	ByType    []LateOrders `json:"by_type"`
+}
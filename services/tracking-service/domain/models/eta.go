@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// ETA is the estimated completion of an order and how much to trust it
+type ETA struct {
+	EstimatedCompletion time.Time `json:"estimated_completion"`
+	// Confidence is between 0 and 1; 1 means the order is already done
+	Confidence    float64 `json:"confidence"`
+	QueuePosition int     `json:"queue_position"`
+	ActiveWorkers int     `json:"active_workers"`
+	// CookSeconds is the cook time the estimate assumes, in simulated seconds
+	CookSeconds float64 `json:"cook_seconds"`
+	// HistorySamples is how many past cook metrics went into CookSeconds
+	HistorySamples int `json:"history_samples"`
+}
+
+// ETAInputs is the order state the estimator needs from the database
+type ETAInputs struct {
+	OrderNumber string
+	OrderType   string
+	Status      string
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+	// QueuedAhead counts received orders of the same type the kitchen will take first
+	QueuedAhead int
+	// CookingNow counts orders of the same type currently being cooked
+	CookingNow int
+}
+
+// CookTimeStats are actual cook times from order_cook_metrics, in simulated seconds
+type CookTimeStats struct {
+	Samples       int
+	AvgSeconds    float64
+	StddevSeconds float64
+}
+
+// QueuePosition tells a waiting customer how many orders are ahead of theirs
+type QueuePosition struct {
+	OrderNumber string `json:"order_number"`
+	OrderType   string `json:"order_type"`
+	Status      string `json:"status"`
+	// Position is 1-based among received orders of the same type; 0 once the order left the queue
+	Position      int `json:"position"`
+	OrdersAhead   int `json:"orders_ahead"`
+	ActiveWorkers int `json:"active_workers"`
+}
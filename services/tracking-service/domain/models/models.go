@@ -4,21 +4,148 @@ import "time"
 
 type OrderStatusResponse struct {
 	OrderNumber         string     `json:"order_number"`
+	OrderType           string     `json:"order_type,omitempty"`
 	CurrentStatus       string     `json:"current_status"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 	EstimatedCompletion *time.Time `json:"estimated_completion,omitempty"`
 	ProcessedBy         *string    `json:"processed_by,omitempty"`
+	ETA                 *ETA       `json:"eta,omitempty"`
+}
+
+type BatchStatusRequest struct {
+	OrderNumbers []string `json:"order_numbers"`
+}
+
+// BatchStatusResult is one requested order; Order is nil when Found is false
+type BatchStatusResult struct {
+	OrderNumber string               `json:"order_number"`
+	Found       bool                 `json:"found"`
+	Order       *OrderStatusResponse `json:"order,omitempty"`
+}
+
+// PublicOrderStatus is what a customer sees on /track/{token}: no worker names or notes
+type PublicOrderStatus struct {
+	OrderNumber         string              `json:"order_number"`
+	Status              string              `json:"status"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+	EstimatedCompletion *time.Time          `json:"estimated_completion,omitempty"`
+	ETAConfidence       *float64            `json:"eta_confidence,omitempty"`
+	QueuePosition       int                 `json:"queue_position,omitempty"`
+	History             []PublicStatusEntry `json:"history"`
+}
+
+type PublicStatusEntry struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// OrderStateAt is an order's status at a past moment, rebuilt from order_status_log
+type OrderStateAt struct {
+	OrderNumber string    `json:"order_number"`
+	At          time.Time `json:"at"`
+	Status      string    `json:"status"`
+	ChangedAt   time.Time `json:"changed_at"`
+	ChangedBy   string    `json:"changed_by"`
 }
 
 type StatusHistory struct {
 	Status    string    `json:"status"`
 	Timestamp time.Time `json:"timestamp"`
 	ChangedBy string    `json:"changed_by"`
+	Notes     *string   `json:"notes,omitempty"`
+	// DurationSeconds is the time until the next entry, or until now for the current status
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	// Retry marks a repeat of the previous status, Reversion a step back in the workflow
+	Retry     bool `json:"retry,omitempty"`
+	Reversion bool `json:"reversion,omitempty"`
+}
+
+type OrderHistory struct {
+	OrderNumber   string `json:"order_number"`
+	CurrentStatus string `json:"current_status,omitempty"`
+	// TotalElapsedSeconds runs from the first entry to the final status, or to now while the order is open
+	TotalElapsedSeconds float64         `json:"total_elapsed_seconds"`
+	Entries             []StatusHistory `json:"entries"`
 }
 
 type WorkerStatus struct {
-	WorkerName      string    `json:"worker_name"`
-	Status          string    `json:"status"`
-	OrdersProcessed int       `json:"orders_processed"`
-	LastSeen        time.Time `json:"last_seen"`
+	WorkerName string `json:"worker_name"`
+	Type       string `json:"type,omitempty"`
+	// Status is the derived liveness; StoredStatus is what the worker last wrote to workers.status
+	Status                   string    `json:"status"`
+	StoredStatus             string    `json:"stored_status"`
+	HeartbeatIntervalSeconds int       `json:"heartbeat_interval_seconds"`
+	OrdersProcessed          int       `json:"orders_processed"`
+	LastSeen                 time.Time `json:"last_seen"`
+}
+
+type WorkerOrder struct {
+	OrderNumber string    `json:"order_number"`
+	OrderType   string    `json:"order_type"`
+	Status      string    `json:"status"`
+	TotalAmount float64   `json:"total_amount"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DailyTotal is one day of a worker's cook metrics
+type DailyTotal struct {
+	Date             string  `json:"date"`
+	OrdersCooked     int     `json:"orders_cooked"`
+	AvgActualSeconds float64 `json:"avg_actual_seconds"`
+	OverdueCount     int     `json:"overdue_count"`
+}
+
+type WorkerDetail struct {
+	WorkerStatus
+	LivenessThresholdSeconds float64       `json:"liveness_threshold_seconds"`
+	CurrentOrders            []WorkerOrder `json:"current_orders"`
+	RecentOrders             []WorkerOrder `json:"recent_orders"`
+	DailyTotals              []DailyTotal  `json:"daily_totals"`
+}
+
+type WorkerMetrics struct {
+	WorkerName          string    `json:"worker_name"`
+	From                time.Time `json:"from"`
+	To                  time.Time `json:"to"`
+	OrdersCooked        int       `json:"orders_cooked"`
+	AvgEstimatedSeconds float64   `json:"avg_estimated_seconds"`
+	AvgActualSeconds    float64   `json:"avg_actual_seconds"`
+	P50ActualSeconds    float64   `json:"p50_actual_seconds"`
+	P90ActualSeconds    float64   `json:"p90_actual_seconds"`
+	P95ActualSeconds    float64   `json:"p95_actual_seconds"`
+	OverdueCount        int       `json:"overdue_count"`
+	ThroughputPerHour   float64   `json:"throughput_per_hour"`
+}
+
+// StatusEvent is a status change from notifications_fanout, or a replay of the current state
+type StatusEvent struct {
+	OrderNumber         string     `json:"order_number"`
+	OrderType           string     `json:"order_type,omitempty"`
+	OldStatus           string     `json:"old_status,omitempty"`
+	NewStatus           string     `json:"new_status"`
+	ChangedBy           string     `json:"changed_by,omitempty"`
+	Timestamp           time.Time  `json:"timestamp"`
+	EstimatedCompletion *time.Time `json:"estimated_completion,omitempty"`
+	Replay              bool       `json:"replay,omitempty"`
+}
+
+// NotificationDelivery is one notification-service attempt to tell someone about an order
+type NotificationDelivery struct {
+	Channel string `json:"channel"`
+	// Recipient is the customer's email or phone; empty for operator channels
+	Recipient     *string    `json:"recipient,omitempty"`
+	OrderStatus   string     `json:"order_status"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type OrderNotifications struct {
+	OrderNumber string `json:"order_number"`
+	// CustomerInformed is set once a customer channel (email, sms) delivered anything
+	CustomerInformed bool                   `json:"customer_informed"`
+	Deliveries       []NotificationDelivery `json:"deliveries"`
 }
@@ -3,13 +3,56 @@ package ports
 import (
 	"context"
 	"restaurant-system/services/tracking-service/domain/models"
+	"time"
 )
 
 type OrderRepository interface {
 	GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderStatusResponse, error)
 	GetOrderStatusHistory(ctx context.Context, orderNumber string) ([]models.StatusHistory, error)
+	// GetOrderNumberByToken resolves a customer tracking token
+	GetOrderNumberByToken(ctx context.Context, token string) (string, error)
+	// GetOrdersByNumbers fetches several orders in one query; unknown numbers are simply absent
+	GetOrdersByNumbers(ctx context.Context, orderNumbers []string) ([]models.OrderStatusResponse, error)
+	// GetActiveOrders returns received and cooking orders, and ready orders updated since readySince
+	GetActiveOrders(ctx context.Context, readySince time.Time) ([]models.OrderStatusResponse, error)
+	// GetOrderStateAt returns the last status logged at or before at; pgx.ErrNoRows if there was none
+	GetOrderStateAt(ctx context.Context, orderNumber string, at time.Time) (models.OrderStateAt, error)
+	// GetAllOrderStatesAt does the same for every order that had a status at that moment
+	GetAllOrderStatesAt(ctx context.Context, at time.Time) ([]models.OrderStateAt, error)
+	// GetETAInputs returns the order's type, status timestamps and the kitchen queue ahead of it
+	GetETAInputs(ctx context.Context, orderNumber string) (models.ETAInputs, error)
+	// GetETAInputsByNumbers does the same for several orders in one query; unknown numbers are simply absent
+	GetETAInputsByNumbers(ctx context.Context, orderNumbers []string) ([]models.ETAInputs, error)
+	// GetNotificationDeliveries lists notification-service's delivery log for the order, oldest first
+	GetNotificationDeliveries(ctx context.Context, orderNumber string) ([]models.NotificationDelivery, error)
 }
 
 type WorkerRepository interface {
 	GetAllWorkersStatus(ctx context.Context) ([]models.WorkerStatus, error)
+	GetWorkerStatus(ctx context.Context, workerName string) (models.WorkerStatus, error)
+	// GetWorkerOrders returns orders last processed by the worker in one of statuses, newest first
+	GetWorkerOrders(ctx context.Context, workerName string, statuses []string, limit int) ([]models.WorkerOrder, error)
+	// GetWorkerDailyTotals groups the worker's cook metrics by day since from
+	GetWorkerDailyTotals(ctx context.Context, workerName string, from time.Time) ([]models.DailyTotal, error)
+}
+
+type MetricsRepository interface {
+	// GetWorkerMetrics aggregates cook metrics finished in [from, to); empty workerName means all workers
+	GetWorkerMetrics(ctx context.Context, workerName string, from, to time.Time) ([]models.WorkerMetrics, error)
+	// GetCookTimeStats summarises the most recent actual cook times for an order type
+	GetCookTimeStats(ctx context.Context, orderType string, limit int) (models.CookTimeStats, error)
+}
+
+// AnalyticsRepository aggregates orders created in [from, to)
+type AnalyticsRepository interface {
+	// GetOrdersByPeriod buckets orders by date_trunc(bucket, created_at); bucket is "hour" or "day"
+	GetOrdersByPeriod(ctx context.Context, from, to time.Time, bucket string) ([]models.PeriodStats, error)
+	GetStatusDurations(ctx context.Context, from, to time.Time) ([]models.StatusDuration, error)
+	GetBreakdown(ctx context.Context, from, to time.Time) ([]models.BreakdownEntry, error)
+	// GetLateOrders compares ready times with deadlines; now and timeScale decide which open orders are overdue
+	GetLateOrders(ctx context.Context, from, to, now time.Time, timeScale float64) ([]models.LateOrders, error)
+}
+
+type StatusEventConsumer interface {
+	ConsumeStatusEvents(ctx context.Context) (<-chan models.StatusEvent, error)
 }
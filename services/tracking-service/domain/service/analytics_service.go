@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"time"
+)
+
+type AnalyticsService struct {
+	AnalyticsRepo ports.AnalyticsRepository
+	Clock         ports.Clock
+}
+
+func NewAnalyticsService(analyticsRepo ports.AnalyticsRepository, clock ports.Clock) *AnalyticsService {
+	return &AnalyticsService{
+		AnalyticsRepo: analyticsRepo,
+		Clock:         clock,
+	}
+}
+
+// GetThroughput returns orders and revenue per hour or day, plus totals for the window
+func (s *AnalyticsService) GetThroughput(ctx context.Context, from, to time.Time, bucket string) (models.ThroughputReport, error) {
+	log.Printf("Getting throughput from %s to %s by %s", from.Format(time.RFC3339), to.Format(time.RFC3339), bucket)
+	if bucket != "hour" && bucket != "day" {
+		return models.ThroughputReport{}, fmt.Errorf("validation failed: bucket must be 'hour' or 'day'")
+	}
+
+	periods, err := s.AnalyticsRepo.GetOrdersByPeriod(ctx, from, to, bucket)
+	if err != nil {
+		return models.ThroughputReport{}, err
+	}
+
+	report := models.ThroughputReport{From: from, To: to, Bucket: bucket, Periods: periods}
+	for _, period := range periods {
+		report.TotalOrders += period.Orders
+		report.TotalRevenue += period.Revenue
+	}
+	if hours := to.Sub(from).Hours(); hours > 0 {
+		report.OrdersPerHour = round2(float64(report.TotalOrders) / hours)
+	}
+	report.TotalRevenue = round2(report.TotalRevenue)
+
+	return report, nil
+}
+
+func (s *AnalyticsService) GetStatusDurations(ctx context.Context, from, to time.Time) ([]models.StatusDuration, error) {
+	log.Printf("Getting status durations from %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	return s.AnalyticsRepo.GetStatusDurations(ctx, from, to)
+}
+
+func (s *AnalyticsService) GetBreakdown(ctx context.Context, from, to time.Time) ([]models.BreakdownEntry, error) {
+	log.Printf("Getting order breakdown from %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	return s.AnalyticsRepo.GetBreakdown(ctx, from, to)
+}
+
+func (s *AnalyticsService) GetLateOrders(ctx context.Context, from, to time.Time) (models.LateReport, error) {
+	log.Printf("Getting late orders from %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	byType, err := s.AnalyticsRepo.GetLateOrders(ctx, from, to, s.Clock.Now(), s.Clock.Scale())
+	if err != nil {
+		return models.LateReport{}, err
+	}
+
+	report := models.LateReport{From: from, To: to, ByType: byType}
+	for _, entry := range byType {
+		report.Orders += entry.Orders
+		report.Late += entry.Late
+		report.LateOpen += entry.LateOpen
+	}
+	if report.Orders > 0 {
+		report.LateRatio = round2(float64(report.Late+report.LateOpen) / float64(report.Orders))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/shared/clock"
+)
+
+type fakeAnalyticsRepo struct {
+	ports.AnalyticsRepository
+	periods []models.PeriodStats
+	late    []models.LateOrders
+
+	gotBucket string
+	gotNow    time.Time
+	gotScale  float64
+}
+
+func (r *fakeAnalyticsRepo) GetOrdersByPeriod(ctx context.Context, from, to time.Time, bucket string) ([]models.PeriodStats, error) {
+	r.gotBucket = bucket
+	return r.periods, nil
+}
+
+func (r *fakeAnalyticsRepo) GetLateOrders(ctx context.Context, from, to, now time.Time, timeScale float64) ([]models.LateOrders, error) {
+	r.gotNow, r.gotScale = now, timeScale
+	return r.late, nil
+}
+
+func TestGetThroughput(t *testing.T) {
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	periods := []models.PeriodStats{
+		{PeriodStart: from, Orders: 4, Revenue: 10.25},
+		{PeriodStart: from.Add(time.Hour), Orders: 3, Revenue: 20},
+	}
+
+	tests := []struct {
+		name        string
+		bucket      string
+		window      time.Duration
+		wantErr     bool
+		wantOrders  int
+		wantRevenue float64
+		wantPerHour float64
+	}{
+		{"hourly", "hour", 3 * time.Hour, false, 7, 30.25, 2.33},
+		{"daily", "day", 24 * time.Hour, false, 7, 30.25, 0.29},
+		{"unknown bucket", "week", 3 * time.Hour, true, 0, 0, 0},
+		{"empty bucket", "", 3 * time.Hour, true, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAnalyticsRepo{periods: periods}
+			s := NewAnalyticsService(repo, clock.NewManual(from))
+
+			report, err := s.GetThroughput(context.Background(), from, from.Add(tt.window), tt.bucket)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "validation failed") {
+					t.Fatalf("err = %v, want a validation error", err)
+				}
+				if repo.gotBucket != "" {
+					t.Error("repository queried with an invalid bucket")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetThroughput: %v", err)
+			}
+			if repo.gotBucket != tt.bucket {
+				t.Errorf("bucket passed = %q, want %q", repo.gotBucket, tt.bucket)
+			}
+			if report.TotalOrders != tt.wantOrders || report.TotalRevenue != tt.wantRevenue || report.OrdersPerHour != tt.wantPerHour {
+				t.Errorf("totals = %d, %v, %v/h; want %d, %v, %v/h",
+					report.TotalOrders, report.TotalRevenue, report.OrdersPerHour, tt.wantOrders, tt.wantRevenue, tt.wantPerHour)
+			}
+			if len(report.Periods) != len(periods) {
+				t.Errorf("got %d periods, want %d", len(report.Periods), len(periods))
+			}
+		})
+	}
+}
+
+func TestGetLateOrders(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		late      []models.LateOrders
+		wantTotal models.LateReport
+	}{
+		{
+			name: "late and still open both count",
+			late: []models.LateOrders{
+				{OrderType: "dine_in", Orders: 4, Late: 1},
+				{OrderType: "delivery", Orders: 2, Late: 0, LateOpen: 1},
+			},
+			wantTotal: models.LateReport{Orders: 6, Late: 1, LateOpen: 1, LateRatio: 0.33},
+		},
+		{
+			name:      "no orders has no ratio",
+			wantTotal: models.LateReport{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAnalyticsRepo{late: tt.late}
+			s := NewAnalyticsService(repo, clock.NewManual(now))
+
+			report, err := s.GetLateOrders(context.Background(), now.Add(-time.Hour), now)
+			if err != nil {
+				t.Fatalf("GetLateOrders: %v", err)
+			}
+			if report.Orders != tt.wantTotal.Orders || report.Late != tt.wantTotal.Late ||
+				report.LateOpen != tt.wantTotal.LateOpen || report.LateRatio != tt.wantTotal.LateRatio {
+				t.Errorf("report = %d orders, %d late, %d late open, ratio %v; want %d, %d, %d, %v",
+					report.Orders, report.Late, report.LateOpen, report.LateRatio,
+					tt.wantTotal.Orders, tt.wantTotal.Late, tt.wantTotal.LateOpen, tt.wantTotal.LateRatio)
+			}
+			// open orders are judged against the service clock, not the window end
+			if !repo.gotNow.Equal(now) || repo.gotScale != 1 {
+				t.Errorf("repository got now %v, scale %v; want %v, 1", repo.gotNow, repo.gotScale, now)
+			}
+		})
+	}
+}
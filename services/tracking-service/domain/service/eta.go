@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"math"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/shared/kitchen"
+	"strings"
+	"time"
+)
+
+const (
+	// how many recent cook metrics feed the historical average
+	etaHistoryLimit = 200
+	// history outweighs the base cook time once there are this many samples
+	etaHistoryPrior = 10
+)
+
+// EstimateCompletion predicts when an order will be ready. Cook time is the kitchen's
+// base time for the order type blended with recent actual cook times (the kitchen has
+// no per-item times, so neither does the estimate); orders still in the queue also
+// wait for everything ahead of them, split across the live workers that take this
+// order type. Returns nil for cancelled orders.
+func (s *TrackingService) EstimateCompletion(ctx context.Context, orderNumber string) (*models.ETA, error) {
+	inputs, err := s.OrderRepo.GetETAInputs(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.newETASources().estimate(ctx, inputs)
+}
+
+// etaSources loads what every estimate shares at most once: worker statuses once,
+// cook time history once per order type. One value serves one request.
+type etaSources struct {
+	tracking      *TrackingService
+	workers       []models.WorkerStatus
+	workersLoaded bool
+	stats         map[string]models.CookTimeStats
+}
+
+func (s *TrackingService) newETASources() *etaSources {
+	return &etaSources{tracking: s, stats: make(map[string]models.CookTimeStats)}
+}
+
+func (e *etaSources) cookTimeStats(ctx context.Context, orderType string) (models.CookTimeStats, error) {
+	if stats, ok := e.stats[orderType]; ok {
+		return stats, nil
+	}
+	stats, err := e.tracking.MetricsRepo.GetCookTimeStats(ctx, orderType, etaHistoryLimit)
+	if err != nil {
+		return models.CookTimeStats{}, err
+	}
+	e.stats[orderType] = stats
+	return stats, nil
+}
+
+func (e *etaSources) workerStatuses(ctx context.Context) ([]models.WorkerStatus, error) {
+	if e.workersLoaded {
+		return e.workers, nil
+	}
+	workers, err := e.tracking.WorkerRepo.GetAllWorkersStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e.workers, e.workersLoaded = workers, true
+	return workers, nil
+}
+
+func (e *etaSources) estimate(ctx context.Context, inputs models.ETAInputs) (*models.ETA, error) {
+	s := e.tracking
+
+	switch inputs.Status {
+	case "cancelled":
+		return nil, nil
+	case "ready", "completed":
+		done := inputs.UpdatedAt
+		if inputs.CompletedAt != nil {
+			done = *inputs.CompletedAt
+		}
+		return &models.ETA{EstimatedCompletion: done, Confidence: 1}, nil
+	}
+
+	stats, err := e.cookTimeStats(ctx, inputs.OrderType)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := e.workerStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cook, historyWeight := blendCookTime(kitchen.CookingTime(inputs.OrderType), stats)
+	activeWorkers := s.countActiveWorkers(workers, inputs.OrderType)
+
+	eta := &models.ETA{
+		ActiveWorkers:  activeWorkers,
+		CookSeconds:    round2(cook.Seconds()),
+		HistorySamples: stats.Samples,
+	}
+
+	// spread of past cook times; 0 when there is no history
+	variability := 0.0
+	if stats.Samples > 1 && stats.AvgSeconds > 0 {
+		variability = stats.StddevSeconds / stats.AvgSeconds
+	}
+	confidence := 0.4 + 0.5*historyWeight/(1+variability)
+
+	var remaining time.Duration
+	switch inputs.Status {
+	case "cooking":
+		// the order has been cooking since its last status change
+		remaining = cook - s.Clock.Since(inputs.UpdatedAt)
+		if remaining < 0 {
+			// running late: expect it any moment, but trust the estimate less
+			remaining = 0
+			confidence *= 0.7
+		}
+	default:
+		eta.QueuePosition = inputs.QueuedAhead + 1
+
+		workersForQueue := activeWorkers
+		if workersForQueue == 0 {
+			// nobody is cooking this type right now; assume one worker will show up
+			workersForQueue = 1
+			confidence *= 0.5
+		}
+		// orders on the stove are on average half done
+		ahead := float64(inputs.QueuedAhead) + 0.5*float64(inputs.CookingNow)
+		wait := time.Duration(ahead / float64(workersForQueue) * float64(cook))
+		remaining = wait + cook
+
+		// every order ahead adds its own variance
+		confidence /= 1 + 0.1*ahead/float64(workersForQueue)
+	}
+
+	eta.EstimatedCompletion = s.Clock.Now().Add(s.wallDuration(remaining))
+	eta.Confidence = round2(confidence)
+	return eta, nil
+}
+
+// blendCookTime weighs the historical average against the base time by sample count
+func blendCookTime(base time.Duration, stats models.CookTimeStats) (time.Duration, float64) {
+	if stats.Samples == 0 || stats.AvgSeconds <= 0 {
+		return base, 0
+	}
+	weight := float64(stats.Samples) / float64(stats.Samples+etaHistoryPrior)
+	seconds := weight*stats.AvgSeconds + (1-weight)*base.Seconds()
+	return time.Duration(seconds * float64(time.Second)), weight
+}
+
+// countActiveWorkers counts online workers whose type list covers orderType (empty list = all types)
+func (s *TrackingService) countActiveWorkers(workers []models.WorkerStatus, orderType string) int {
+	count := 0
+	for _, worker := range workers {
+		if !s.isWorkerOnline(worker) {
+			continue
+		}
+		if worker.Type == "" {
+			count++
+			continue
+		}
+		for _, t := range strings.Split(worker.Type, ",") {
+			if strings.TrimSpace(t) == orderType {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// wallDuration converts a simulated duration to real time under --time-scale
+func (s *TrackingService) wallDuration(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / s.Clock.Scale())
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// GetQueuePosition places an order among received orders of its type, in the order
+// the kitchen takes them (higher priority first, then oldest first)
+func (s *TrackingService) GetQueuePosition(ctx context.Context, orderNumber string) (models.QueuePosition, error) {
+	inputs, err := s.OrderRepo.GetETAInputs(ctx, orderNumber)
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+	workers, err := s.WorkerRepo.GetAllWorkersStatus(ctx)
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+
+	position := models.QueuePosition{
+		OrderNumber:   orderNumber,
+		OrderType:     inputs.OrderType,
+		Status:        inputs.Status,
+		ActiveWorkers: s.countActiveWorkers(workers, inputs.OrderType),
+	}
+	if inputs.Status == "received" {
+		position.OrdersAhead = inputs.QueuedAhead
+		position.Position = inputs.QueuedAhead + 1
+	}
+
+	return position, nil
+}
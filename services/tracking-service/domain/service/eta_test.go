@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/shared/clock"
+)
+
+type fakeOrderRepo struct {
+	ports.OrderRepository
+	inputs  models.ETAInputs
+	history []models.StatusHistory
+}
+
+func (r fakeOrderRepo) GetETAInputs(ctx context.Context, orderNumber string) (models.ETAInputs, error) {
+	return r.inputs, nil
+}
+
+type fakeWorkerRepo struct {
+	ports.WorkerRepository
+	workers []models.WorkerStatus
+}
+
+func (r fakeWorkerRepo) GetAllWorkersStatus(ctx context.Context) ([]models.WorkerStatus, error) {
+	return r.workers, nil
+}
+
+type fakeMetricsRepo struct {
+	ports.MetricsRepository
+	stats models.CookTimeStats
+}
+
+func (r fakeMetricsRepo) GetCookTimeStats(ctx context.Context, orderType string, limit int) (models.CookTimeStats, error) {
+	return r.stats, nil
+}
+
+func TestBlendCookTime(t *testing.T) {
+	base := 10 * time.Second
+	tests := []struct {
+		name       string
+		stats      models.CookTimeStats
+		wantCook   time.Duration
+		wantWeight float64
+	}{
+		{"no history", models.CookTimeStats{}, base, 0},
+		{"zero average", models.CookTimeStats{Samples: 5}, base, 0},
+		{"prior sized history", models.CookTimeStats{Samples: etaHistoryPrior, AvgSeconds: 20}, 15 * time.Second, 0.5},
+		{"long history", models.CookTimeStats{Samples: 90, AvgSeconds: 20}, 19 * time.Second, 0.9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cook, weight := blendCookTime(base, tt.stats)
+			if cook != tt.wantCook || weight != tt.wantWeight {
+				t.Errorf("blendCookTime = %v, %v; want %v, %v", cook, weight, tt.wantCook, tt.wantWeight)
+			}
+		})
+	}
+}
+
+func TestEstimateCompletion(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	done := now.Add(-time.Minute)
+	online := models.WorkerStatus{WorkerName: "chef", Type: "dine_in,takeout", LastSeen: now, HeartbeatIntervalSeconds: 30}
+	offline := models.WorkerStatus{WorkerName: "gone", LastSeen: now.Add(-time.Hour), HeartbeatIntervalSeconds: 30}
+
+	tests := []struct {
+		name           string
+		inputs         models.ETAInputs
+		workers        []models.WorkerStatus
+		want           *models.ETA
+		wantCompletion time.Time
+	}{
+		{
+			name:   "cancelled has no estimate",
+			inputs: models.ETAInputs{OrderType: "dine_in", Status: "cancelled"},
+		},
+		{
+			name:           "ready is done at completion",
+			inputs:         models.ETAInputs{OrderType: "dine_in", Status: "ready", UpdatedAt: now.Add(-2 * time.Minute), CompletedAt: &done},
+			want:           &models.ETA{Confidence: 1},
+			wantCompletion: done,
+		},
+		{
+			name:           "cooking finishes after the rest of the cook time",
+			inputs:         models.ETAInputs{OrderType: "dine_in", Status: "cooking", UpdatedAt: now.Add(-3 * time.Second)},
+			workers:        []models.WorkerStatus{online},
+			want:           &models.ETA{Confidence: 0.4, ActiveWorkers: 1, CookSeconds: 8},
+			wantCompletion: now.Add(5 * time.Second),
+		},
+		{
+			name:           "late cooking is due now",
+			inputs:         models.ETAInputs{OrderType: "dine_in", Status: "cooking", UpdatedAt: now.Add(-time.Minute)},
+			workers:        []models.WorkerStatus{online},
+			want:           &models.ETA{Confidence: 0.28, ActiveWorkers: 1, CookSeconds: 8},
+			wantCompletion: now,
+		},
+		{
+			name:           "queued waits for orders ahead",
+			inputs:         models.ETAInputs{OrderType: "takeout", Status: "received", QueuedAhead: 2, CookingNow: 2},
+			workers:        []models.WorkerStatus{online, offline},
+			want:           &models.ETA{Confidence: 0.31, QueuePosition: 3, ActiveWorkers: 1, CookSeconds: 10},
+			wantCompletion: now.Add(40 * time.Second),
+		},
+		{
+			name:           "queued without workers assumes one",
+			inputs:         models.ETAInputs{OrderType: "delivery", Status: "received"},
+			workers:        []models.WorkerStatus{online},
+			want:           &models.ETA{Confidence: 0.2, QueuePosition: 1, CookSeconds: 12},
+			wantCompletion: now.Add(12 * time.Second),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackingService(fakeOrderRepo{inputs: tt.inputs}, fakeWorkerRepo{workers: tt.workers}, fakeMetricsRepo{}, clock.NewManual(now))
+			got, err := s.EstimateCompletion(context.Background(), "ORD_1")
+			if err != nil {
+				t.Fatalf("EstimateCompletion: %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("EstimateCompletion = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("EstimateCompletion = nil")
+			}
+			want := *tt.want
+			want.EstimatedCompletion = tt.wantCompletion
+			if *got != want {
+				t.Errorf("EstimateCompletion = %+v\nwant %+v", *got, want)
+			}
+		})
+	}
+}
+
+type batchOrderRepo struct {
+	ports.OrderRepository
+	orders []models.OrderStatusResponse
+	inputs []models.ETAInputs
+}
+
+func (r batchOrderRepo) GetOrdersByNumbers(ctx context.Context, orderNumbers []string) ([]models.OrderStatusResponse, error) {
+	return append([]models.OrderStatusResponse(nil), r.orders...), nil
+}
+
+func (r batchOrderRepo) GetETAInputsByNumbers(ctx context.Context, orderNumbers []string) ([]models.ETAInputs, error) {
+	return r.inputs, nil
+}
+
+type countingWorkerRepo struct {
+	ports.WorkerRepository
+	calls int
+}
+
+func (r *countingWorkerRepo) GetAllWorkersStatus(ctx context.Context) ([]models.WorkerStatus, error) {
+	r.calls++
+	return nil, nil
+}
+
+type countingMetricsRepo struct {
+	ports.MetricsRepository
+	calls map[string]int
+}
+
+func (r *countingMetricsRepo) GetCookTimeStats(ctx context.Context, orderType string, limit int) (models.CookTimeStats, error) {
+	r.calls[orderType]++
+	return models.CookTimeStats{}, nil
+}
+
+func TestGetOrderStatusesLoadsETASourcesOnce(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	orders := []models.OrderStatusResponse{
+		{OrderNumber: "ORD_1", OrderType: "takeout", CurrentStatus: "received"},
+		{OrderNumber: "ORD_2", OrderType: "takeout", CurrentStatus: "cooking"},
+		{OrderNumber: "ORD_3", OrderType: "delivery", CurrentStatus: "received"},
+		{OrderNumber: "ORD_4", OrderType: "dine_in", CurrentStatus: "ready"},
+	}
+	inputs := []models.ETAInputs{
+		{OrderNumber: "ORD_1", OrderType: "takeout", Status: "received", UpdatedAt: now},
+		{OrderNumber: "ORD_2", OrderType: "takeout", Status: "cooking", UpdatedAt: now},
+		{OrderNumber: "ORD_3", OrderType: "delivery", Status: "received", UpdatedAt: now},
+		{OrderNumber: "ORD_4", OrderType: "dine_in", Status: "ready", UpdatedAt: now},
+	}
+
+	workers := &countingWorkerRepo{}
+	metrics := &countingMetricsRepo{calls: make(map[string]int)}
+	s := NewTrackingService(batchOrderRepo{orders: orders, inputs: inputs}, workers, metrics, clock.NewManual(now))
+
+	results, err := s.GetOrderStatuses(context.Background(), []string{"ORD_1", "ORD_2", "ORD_3", "ORD_4"})
+	if err != nil {
+		t.Fatalf("GetOrderStatuses: %v", err)
+	}
+	for _, result := range results {
+		if result.Order == nil || result.Order.ETA == nil {
+			t.Errorf("%s has no ETA", result.OrderNumber)
+		}
+	}
+
+	if workers.calls != 1 {
+		t.Errorf("worker statuses loaded %d times, want 1", workers.calls)
+	}
+	// ready orders need no history
+	want := map[string]int{"takeout": 1, "delivery": 1}
+	if !maps.Equal(metrics.calls, want) {
+		t.Errorf("cook time stats loaded %v, want %v", metrics.calls, want)
+	}
+}
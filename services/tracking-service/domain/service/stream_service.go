@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"sync"
+	"time"
+)
+
+const (
+	// events buffered per subscriber before it is considered too slow and dropped
+	subscriberBuffer = 32
+	// ready orders older than this are not replayed; matches READY_TTL_MS on the board
+	readyReplayWindow = 10 * time.Minute
+)
+
+// StreamService fans status events from RabbitMQ out to live HTTP subscribers
+type StreamService struct {
+	OrderRepo ports.OrderRepository
+	Consumer  ports.StatusEventConsumer
+	// Tracking estimates completion for replayed orders
+	Tracking *TrackingService
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives events for one order, or for all orders when OrderNumber is empty.
+// Events is closed when the subscriber falls behind or the service stops.
+type Subscription struct {
+	OrderNumber string
+	Events      chan models.StatusEvent
+	closed      bool
+}
+
+func NewStreamService(orderRepo ports.OrderRepository, consumer ports.StatusEventConsumer, tracking *TrackingService) *StreamService {
+	return &StreamService{
+		OrderRepo:   orderRepo,
+		Consumer:    consumer,
+		Tracking:    tracking,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Run consumes status events until ctx is cancelled
+func (s *StreamService) Run(ctx context.Context) error {
+	events, err := s.Consumer.ConsumeStatusEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer s.closeAll()
+	for event := range events {
+		s.broadcast(event)
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber and returns the current state to replay first
+func (s *StreamService) Subscribe(ctx context.Context, orderNumber string) ([]models.StatusEvent, *Subscription, error) {
+	sub := &Subscription{
+		OrderNumber: orderNumber,
+		Events:      make(chan models.StatusEvent, subscriberBuffer),
+	}
+
+	// subscribe before reading the snapshot so nothing falls between the two
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	replay, err := s.currentState(ctx, orderNumber)
+	if err != nil {
+		s.Unsubscribe(sub)
+		return nil, nil, err
+	}
+
+	log.Printf("Stream subscriber added for order %q", orderNumber)
+	return replay, sub, nil
+}
+
+func (s *StreamService) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(sub)
+}
+
+func (s *StreamService) currentState(ctx context.Context, orderNumber string) ([]models.StatusEvent, error) {
+	var orders []models.OrderStatusResponse
+	if orderNumber != "" {
+		order, err := s.OrderRepo.GetOrderByNumber(ctx, orderNumber)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	} else {
+		// ready orders are never completed in the kitchen flow, so only recent ones are replayed
+		active, err := s.OrderRepo.GetActiveOrders(ctx, s.Tracking.Clock.Now().Add(-readyReplayWindow))
+		if err != nil {
+			return nil, err
+		}
+		orders = active
+	}
+
+	s.Tracking.attachETAs(ctx, orders)
+	replay := make([]models.StatusEvent, 0, len(orders))
+	for _, order := range orders {
+		event := models.StatusEvent{
+			OrderNumber:         order.OrderNumber,
+			OrderType:           order.OrderType,
+			NewStatus:           order.CurrentStatus,
+			Timestamp:           order.UpdatedAt,
+			EstimatedCompletion: order.EstimatedCompletion,
+			Replay:              true,
+		}
+		if order.ProcessedBy != nil {
+			event.ChangedBy = *order.ProcessedBy
+		}
+		replay = append(replay, event)
+	}
+	return replay, nil
+}
+
+func (s *StreamService) broadcast(event models.StatusEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		if sub.OrderNumber != "" && sub.OrderNumber != event.OrderNumber {
+			continue
+		}
+		select {
+		case sub.Events <- event:
+		default:
+			// slow client: drop it, it will get a fresh replay on reconnect
+			log.Printf("Dropping slow stream subscriber for order %q", sub.OrderNumber)
+			s.remove(sub)
+		}
+	}
+}
+
+func (s *StreamService) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		s.remove(sub)
+	}
+}
+
+// remove must be called with mu held
+func (s *StreamService) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(s.subscribers, sub)
+	close(sub.Events)
+}
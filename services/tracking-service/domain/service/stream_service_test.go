@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/shared/clock"
+)
+
+type boardOrderRepo struct {
+	ports.OrderRepository
+	orders      []models.OrderStatusResponse
+	inputs      []models.ETAInputs
+	readySince  time.Time
+	inputsCalls int
+}
+
+func (r *boardOrderRepo) GetActiveOrders(ctx context.Context, readySince time.Time) ([]models.OrderStatusResponse, error) {
+	r.readySince = readySince
+	return append([]models.OrderStatusResponse(nil), r.orders...), nil
+}
+
+func (r *boardOrderRepo) GetETAInputsByNumbers(ctx context.Context, orderNumbers []string) ([]models.ETAInputs, error) {
+	r.inputsCalls++
+	return r.inputs, nil
+}
+
+func TestStreamReplayIsBoundedAndBatched(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := &boardOrderRepo{
+		orders: []models.OrderStatusResponse{
+			{OrderNumber: "ORD_1", OrderType: "takeout", CurrentStatus: "received"},
+			{OrderNumber: "ORD_2", OrderType: "takeout", CurrentStatus: "ready"},
+		},
+		inputs: []models.ETAInputs{
+			{OrderNumber: "ORD_1", OrderType: "takeout", Status: "received"},
+			{OrderNumber: "ORD_2", OrderType: "takeout", Status: "ready", UpdatedAt: now.Add(-time.Minute)},
+		},
+	}
+	tracking := NewTrackingService(repo, fakeWorkerRepo{}, fakeMetricsRepo{}, clock.NewManual(now))
+	stream := NewStreamService(repo, nil, tracking)
+
+	replay, err := stream.currentState(context.Background(), "")
+	if err != nil {
+		t.Fatalf("currentState: %v", err)
+	}
+
+	if want := now.Add(-readyReplayWindow); !repo.readySince.Equal(want) {
+		t.Errorf("ready orders replayed since %v, want %v", repo.readySince, want)
+	}
+	if repo.inputsCalls != 1 {
+		t.Errorf("ETA inputs loaded %d times, want 1", repo.inputsCalls)
+	}
+	if len(replay) != 2 {
+		t.Fatalf("replayed %d events, want 2", len(replay))
+	}
+	for _, event := range replay {
+		if event.EstimatedCompletion == nil {
+			t.Errorf("%s replayed without an estimate", event.OrderNumber)
+		}
+	}
+}
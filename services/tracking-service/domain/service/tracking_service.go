@@ -2,32 +2,277 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"restaurant-system/services/tracking-service/domain/models"
 	"restaurant-system/services/tracking-service/domain/ports"
 	"time"
 )
 
+const (
+	// matches the kitchen worker's --heartbeat-interval default
+	defaultHeartbeatInterval = 30 * time.Second
+	// heartbeats a worker may miss before it is reported offline
+	missedHeartbeats = 3
+
+	workerRecentOrders    = 20
+	workerDailyTotalsDays = 7
+)
+
+// MaxBatchStatus caps how many orders one batch status request may ask for
+const MaxBatchStatus = 100
+
 type TrackingService struct {
-	OrderRepo  ports.OrderRepository
-	WorkerRepo ports.WorkerRepository
+	OrderRepo   ports.OrderRepository
+	WorkerRepo  ports.WorkerRepository
+	MetricsRepo ports.MetricsRepository
+	Clock       ports.Clock
 }
 
-func NewTrackingService(orderRepo ports.OrderRepository, workerRepo ports.WorkerRepository) *TrackingService {
+func NewTrackingService(orderRepo ports.OrderRepository, workerRepo ports.WorkerRepository, metricsRepo ports.MetricsRepository, clock ports.Clock) *TrackingService {
 	return &TrackingService{
-		OrderRepo:  orderRepo,
-		WorkerRepo: workerRepo,
+		OrderRepo:   orderRepo,
+		WorkerRepo:  workerRepo,
+		MetricsRepo: metricsRepo,
+		Clock:       clock,
 	}
 }
 
 func (s *TrackingService) GetOrderStatus(ctx context.Context, orderNumber string) (models.OrderStatusResponse, error) {
 	log.Printf("Getting status for order: %s", orderNumber)
-	return s.OrderRepo.GetOrderByNumber(ctx, orderNumber)
+	status, err := s.OrderRepo.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
+		return models.OrderStatusResponse{}, err
+	}
+
+	s.attachETA(ctx, &status)
+	return status, nil
+}
+
+// attachETA fills the estimate; a missing estimate should not hide the status itself
+func (s *TrackingService) attachETA(ctx context.Context, status *models.OrderStatusResponse) {
+	eta, err := s.EstimateCompletion(ctx, status.OrderNumber)
+	if err != nil {
+		log.Printf("Failed to estimate completion for order %s: %v", status.OrderNumber, err)
+		return
+	}
+	setETA(status, eta)
 }
 
-func (s *TrackingService) GetOrderHistory(ctx context.Context, orderNumber string) ([]models.StatusHistory, error) {
+// attachETAs estimates several orders with one ETA inputs query, one worker
+// status query and one cook time history query per order type
+func (s *TrackingService) attachETAs(ctx context.Context, orders []models.OrderStatusResponse) {
+	if len(orders) == 0 {
+		return
+	}
+	numbers := make([]string, len(orders))
+	for i := range orders {
+		numbers[i] = orders[i].OrderNumber
+	}
+
+	inputs, err := s.OrderRepo.GetETAInputsByNumbers(ctx, numbers)
+	if err != nil {
+		log.Printf("Failed to load ETA inputs for %d orders: %v", len(orders), err)
+		return
+	}
+	byNumber := make(map[string]models.ETAInputs, len(inputs))
+	for _, in := range inputs {
+		byNumber[in.OrderNumber] = in
+	}
+
+	sources := s.newETASources()
+	for i := range orders {
+		in, ok := byNumber[orders[i].OrderNumber]
+		if !ok {
+			continue
+		}
+		eta, err := sources.estimate(ctx, in)
+		if err != nil {
+			log.Printf("Failed to estimate completion for order %s: %v", orders[i].OrderNumber, err)
+			continue
+		}
+		setETA(&orders[i], eta)
+	}
+}
+
+func setETA(status *models.OrderStatusResponse, eta *models.ETA) {
+	if eta != nil {
+		status.ETA = eta
+		status.EstimatedCompletion = &eta.EstimatedCompletion
+	}
+}
+
+// GetOrderStatuses looks up to MaxBatchStatus orders at once, keeping the request order
+// and marking unknown numbers instead of failing
+func (s *TrackingService) GetOrderStatuses(ctx context.Context, orderNumbers []string) ([]models.BatchStatusResult, error) {
+	log.Printf("Getting status for %d orders", len(orderNumbers))
+	if len(orderNumbers) == 0 {
+		return nil, fmt.Errorf("validation failed: order_numbers is required")
+	}
+	if len(orderNumbers) > MaxBatchStatus {
+		return nil, fmt.Errorf("validation failed: at most %d order numbers per request", MaxBatchStatus)
+	}
+
+	orders, err := s.OrderRepo.GetOrdersByNumbers(ctx, orderNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachETAs(ctx, orders)
+
+	byNumber := make(map[string]*models.OrderStatusResponse, len(orders))
+	for i := range orders {
+		byNumber[orders[i].OrderNumber] = &orders[i]
+	}
+
+	results := make([]models.BatchStatusResult, 0, len(orderNumbers))
+	for _, number := range orderNumbers {
+		order, found := byNumber[number]
+		results = append(results, models.BatchStatusResult{OrderNumber: number, Found: found, Order: order})
+	}
+
+	return results, nil
+}
+
+// ResolveTrackingToken maps a customer's tracking token to the order number
+func (s *TrackingService) ResolveTrackingToken(ctx context.Context, token string) (string, error) {
+	return s.OrderRepo.GetOrderNumberByToken(ctx, token)
+}
+
+// GetPublicOrderStatus returns the customer view of an order, without internal fields
+func (s *TrackingService) GetPublicOrderStatus(ctx context.Context, token string) (models.PublicOrderStatus, error) {
+	orderNumber, err := s.ResolveTrackingToken(ctx, token)
+	if err != nil {
+		return models.PublicOrderStatus{}, err
+	}
+
+	status, err := s.GetOrderStatus(ctx, orderNumber)
+	if err != nil {
+		return models.PublicOrderStatus{}, err
+	}
+	history, err := s.OrderRepo.GetOrderStatusHistory(ctx, orderNumber)
+	if err != nil {
+		return models.PublicOrderStatus{}, err
+	}
+
+	public := models.PublicOrderStatus{
+		OrderNumber:         status.OrderNumber,
+		Status:              status.CurrentStatus,
+		UpdatedAt:           status.UpdatedAt,
+		EstimatedCompletion: status.EstimatedCompletion,
+		History:             make([]models.PublicStatusEntry, 0, len(history)),
+	}
+	if status.ETA != nil {
+		public.ETAConfidence = &status.ETA.Confidence
+		public.QueuePosition = status.ETA.QueuePosition
+	}
+	for _, entry := range history {
+		public.History = append(public.History, models.PublicStatusEntry{Status: entry.Status, Timestamp: entry.Timestamp})
+	}
+
+	return public, nil
+}
+
+// GetOrderStatusAt rebuilds the order's status at a past moment from the status log
+func (s *TrackingService) GetOrderStatusAt(ctx context.Context, orderNumber string, at time.Time) (models.OrderStateAt, error) {
+	log.Printf("Getting status for order %s at %s", orderNumber, at.Format(time.RFC3339))
+	return s.OrderRepo.GetOrderStateAt(ctx, orderNumber, at)
+}
+
+// GetAllOrderStatusesAt returns every order that existed at the given moment with its status then
+func (s *TrackingService) GetAllOrderStatusesAt(ctx context.Context, at time.Time) ([]models.OrderStateAt, error) {
+	log.Printf("Getting status of all orders at %s", at.Format(time.RFC3339))
+	return s.OrderRepo.GetAllOrderStatesAt(ctx, at)
+}
+
+// GetOrderNotifications shows support whether and how the customer was told about the order
+func (s *TrackingService) GetOrderNotifications(ctx context.Context, orderNumber string) (models.OrderNotifications, error) {
+	log.Printf("Getting notifications for order: %s", orderNumber)
+	if _, err := s.OrderRepo.GetOrderByNumber(ctx, orderNumber); err != nil {
+		return models.OrderNotifications{}, err
+	}
+
+	deliveries, err := s.OrderRepo.GetNotificationDeliveries(ctx, orderNumber)
+	if err != nil {
+		return models.OrderNotifications{}, err
+	}
+
+	notifications := models.OrderNotifications{OrderNumber: orderNumber, Deliveries: deliveries}
+	for _, delivery := range deliveries {
+		if delivery.Recipient != nil && delivery.Status == "sent" {
+			notifications.CustomerInformed = true
+			break
+		}
+	}
+
+	return notifications, nil
+}
+
+func (s *TrackingService) GetOrderHistory(ctx context.Context, orderNumber string) (models.OrderHistory, error) {
 	log.Printf("Getting history for order: %s", orderNumber)
-	return s.OrderRepo.GetOrderStatusHistory(ctx, orderNumber)
+	entries, err := s.OrderRepo.GetOrderStatusHistory(ctx, orderNumber)
+	if err != nil {
+		return models.OrderHistory{}, err
+	}
+
+	history := models.OrderHistory{OrderNumber: orderNumber, Entries: entries}
+	if len(entries) == 0 {
+		history.Entries = []models.StatusHistory{}
+		return history, nil
+	}
+
+	for i := range entries {
+		if i > 0 {
+			prev := entries[i-1].Status
+			entries[i].Retry = entries[i].Status == prev
+			rank, prevRank := statusRank(entries[i].Status), statusRank(prev)
+			entries[i].Reversion = rank >= 0 && prevRank >= 0 && rank < prevRank
+		}
+
+		var end time.Time
+		switch {
+		case i+1 < len(entries):
+			end = entries[i+1].Timestamp
+		case isFinalStatus(entries[i].Status):
+			// nothing left to wait for
+			continue
+		default:
+			end = s.Clock.Now()
+		}
+		seconds := round2(end.Sub(entries[i].Timestamp).Seconds())
+		entries[i].DurationSeconds = &seconds
+	}
+
+	first, last := entries[0], entries[len(entries)-1]
+	history.CurrentStatus = last.Status
+	end := last.Timestamp
+	if !isFinalStatus(last.Status) {
+		end = s.Clock.Now()
+	}
+	history.TotalElapsedSeconds = round2(end.Sub(first.Timestamp).Seconds())
+
+	return history, nil
+}
+
+// statusRank orders the workflow received → cooking → ready → completed; -1 for unknown statuses
+func statusRank(status string) int {
+	switch status {
+	case "received":
+		return 0
+	case "cooking":
+		return 1
+	case "ready":
+		return 2
+	case "completed", "cancelled":
+		return 3
+	default:
+		return -1
+	}
+}
+
+// isFinalStatus reports whether the kitchen is done with the order
+func isFinalStatus(status string) bool {
+	return status == "ready" || status == "completed" || status == "cancelled"
 }
 
 func (s *TrackingService) GetWorkersStatus(ctx context.Context) ([]models.WorkerStatus, error) {
@@ -37,14 +282,90 @@ func (s *TrackingService) GetWorkersStatus(ctx context.Context) ([]models.Worker
 		return nil, err
 	}
 
-	// Determine online/offline status based on last_seen
+	// Determine online/offline status from the stored status and last_seen
 	for i := range workers {
-		if time.Since(workers[i].LastSeen) > 2*time.Minute { // 2 minutes threshold
-			workers[i].Status = "offline"
-		} else {
+		if s.isWorkerOnline(workers[i]) {
 			workers[i].Status = "online"
+		} else {
+			workers[i].Status = "offline"
 		}
 	}
 
 	return workers, nil
 }
+
+// GetWorkerDetail returns a worker with its current and recent orders and daily totals
+func (s *TrackingService) GetWorkerDetail(ctx context.Context, workerName string) (models.WorkerDetail, error) {
+	log.Printf("Getting details for worker: %s", workerName)
+	worker, err := s.WorkerRepo.GetWorkerStatus(ctx, workerName)
+	if err != nil {
+		return models.WorkerDetail{}, err
+	}
+	if s.isWorkerOnline(worker) {
+		worker.Status = "online"
+	} else {
+		worker.Status = "offline"
+	}
+
+	current, err := s.WorkerRepo.GetWorkerOrders(ctx, workerName, []string{"cooking"}, workerRecentOrders)
+	if err != nil {
+		return models.WorkerDetail{}, err
+	}
+	recent, err := s.WorkerRepo.GetWorkerOrders(ctx, workerName, []string{"ready", "completed"}, workerRecentOrders)
+	if err != nil {
+		return models.WorkerDetail{}, err
+	}
+	totals, err := s.WorkerRepo.GetWorkerDailyTotals(ctx, workerName, s.Clock.Now().AddDate(0, 0, -workerDailyTotalsDays))
+	if err != nil {
+		return models.WorkerDetail{}, err
+	}
+
+	return models.WorkerDetail{
+		WorkerStatus:             worker,
+		LivenessThresholdSeconds: livenessThreshold(worker).Seconds(),
+		CurrentOrders:            current,
+		RecentOrders:             recent,
+		DailyTotals:              totals,
+	}, nil
+}
+
+// isWorkerOnline trusts workers.status for a clean shutdown and last_seen for a crash
+func (s *TrackingService) isWorkerOnline(worker models.WorkerStatus) bool {
+	if worker.StoredStatus == "offline" {
+		return false
+	}
+	return s.Clock.Since(worker.LastSeen) <= livenessThreshold(worker)
+}
+
+// livenessThreshold allows a worker to miss a couple of heartbeats before it counts as offline
+func livenessThreshold(worker models.WorkerStatus) time.Duration {
+	interval := time.Duration(worker.HeartbeatIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	return missedHeartbeats * interval
+}
+
+// GetWorkerMetrics returns per-worker cook statistics for the [from, to) window.
+// An empty workerName returns every worker that cooked something in the window.
+func (s *TrackingService) GetWorkerMetrics(ctx context.Context, workerName string, from, to time.Time) ([]models.WorkerMetrics, error) {
+	log.Printf("Getting metrics for workers %q from %s to %s", workerName, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	metrics, err := s.MetricsRepo.GetWorkerMetrics(ctx, workerName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// a named worker with no cooked orders still gets an (empty) entry
+	if workerName != "" && len(metrics) == 0 {
+		metrics = append(metrics, models.WorkerMetrics{WorkerName: workerName, From: from, To: to})
+	}
+
+	hours := to.Sub(from).Hours()
+	for i := range metrics {
+		if hours > 0 {
+			metrics[i].ThroughputPerHour = float64(metrics[i].OrdersCooked) / hours
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"restaurant-system/services/tracking-service/domain/models"
+	"restaurant-system/services/tracking-service/domain/ports"
+	"restaurant-system/shared/clock"
+)
+
+func (r fakeOrderRepo) GetOrderStatusHistory(ctx context.Context, orderNumber string) ([]models.StatusHistory, error) {
+	return append([]models.StatusHistory(nil), r.history...), nil
+}
+
+func TestStatusRank(t *testing.T) {
+	workflow := []string{"received", "cooking", "ready", "completed"}
+	for i := 1; i < len(workflow); i++ {
+		if statusRank(workflow[i-1]) >= statusRank(workflow[i]) {
+			t.Errorf("statusRank(%s) should be below statusRank(%s)", workflow[i-1], workflow[i])
+		}
+	}
+	if statusRank("cancelled") != statusRank("completed") {
+		t.Error("cancelled and completed should both be final")
+	}
+	if statusRank("lost") != -1 {
+		t.Error("unknown statuses should rank -1")
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+	entry := func(status string, seconds int) models.StatusHistory {
+		return models.StatusHistory{Status: status, Timestamp: at(seconds)}
+	}
+
+	tests := []struct {
+		name          string
+		entries       []models.StatusHistory
+		now           int
+		wantCurrent   string
+		wantElapsed   float64
+		wantDurations []float64 // -1: no duration
+		wantRetry     []bool
+		wantReversion []bool
+	}{
+		{
+			name:          "open order runs until now",
+			entries:       []models.StatusHistory{entry("received", 0), entry("cooking", 5)},
+			now:           12,
+			wantCurrent:   "cooking",
+			wantElapsed:   12,
+			wantDurations: []float64{5, 7},
+			wantRetry:     []bool{false, false},
+			wantReversion: []bool{false, false},
+		},
+		{
+			name:          "final status stops the clock",
+			entries:       []models.StatusHistory{entry("received", 0), entry("cooking", 5), entry("ready", 13)},
+			now:           100,
+			wantCurrent:   "ready",
+			wantElapsed:   13,
+			wantDurations: []float64{5, 8, -1},
+			wantRetry:     []bool{false, false, false},
+			wantReversion: []bool{false, false, false},
+		},
+		{
+			name:          "redelivered order is a retry",
+			entries:       []models.StatusHistory{entry("received", 0), entry("cooking", 2), entry("cooking", 30), entry("ready", 40)},
+			now:           50,
+			wantCurrent:   "ready",
+			wantElapsed:   40,
+			wantDurations: []float64{2, 28, 10, -1},
+			wantRetry:     []bool{false, false, true, false},
+			wantReversion: []bool{false, false, false, false},
+		},
+		{
+			name:          "step back is a reversion",
+			entries:       []models.StatusHistory{entry("received", 0), entry("ready", 10), entry("cooking", 15)},
+			now:           20,
+			wantCurrent:   "cooking",
+			wantElapsed:   20,
+			wantDurations: []float64{10, 5, 5},
+			wantRetry:     []bool{false, false, false},
+			wantReversion: []bool{false, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackingService(fakeOrderRepo{history: tt.entries}, nil, nil, clock.NewManual(at(tt.now)))
+			history, err := s.GetOrderHistory(context.Background(), "ORD_1")
+			if err != nil {
+				t.Fatalf("GetOrderHistory: %v", err)
+			}
+			if history.CurrentStatus != tt.wantCurrent || history.TotalElapsedSeconds != tt.wantElapsed {
+				t.Errorf("current %q elapsed %v, want %q %v", history.CurrentStatus, history.TotalElapsedSeconds, tt.wantCurrent, tt.wantElapsed)
+			}
+			for i, e := range history.Entries {
+				duration := -1.0
+				if e.DurationSeconds != nil {
+					duration = *e.DurationSeconds
+				}
+				if duration != tt.wantDurations[i] || e.Retry != tt.wantRetry[i] || e.Reversion != tt.wantReversion[i] {
+					t.Errorf("entry %d (%s): duration %v retry %v reversion %v, want %v %v %v",
+						i, e.Status, duration, e.Retry, e.Reversion, tt.wantDurations[i], tt.wantRetry[i], tt.wantReversion[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetOrderHistoryEmpty(t *testing.T) {
+	s := NewTrackingService(fakeOrderRepo{}, nil, nil, clock.NewManual(time.Now()))
+	history, err := s.GetOrderHistory(context.Background(), "ORD_1")
+	if err != nil {
+		t.Fatalf("GetOrderHistory: %v", err)
+	}
+	if history.Entries == nil || len(history.Entries) != 0 {
+		t.Errorf("Entries = %#v, want an empty list", history.Entries)
+	}
+}
+
+// stateOrderRepo answers point-in-time lookups and records the moment asked for
+type stateOrderRepo struct {
+	ports.OrderRepository
+	states []models.OrderStateAt
+	gotAt  time.Time
+}
+
+func (r *stateOrderRepo) GetOrderStateAt(ctx context.Context, orderNumber string, at time.Time) (models.OrderStateAt, error) {
+	r.gotAt = at
+	for _, state := range r.states {
+		if state.OrderNumber == orderNumber {
+			return state, nil
+		}
+	}
+	return models.OrderStateAt{}, fmt.Errorf("order %s had no status at %s", orderNumber, at)
+}
+
+func (r *stateOrderRepo) GetAllOrderStatesAt(ctx context.Context, at time.Time) ([]models.OrderStateAt, error) {
+	r.gotAt = at
+	return r.states, nil
+}
+
+func TestGetOrderStatusAt(t *testing.T) {
+	at := time.Date(2026, 10, 19, 11, 30, 0, 0, time.UTC)
+	repo := &stateOrderRepo{states: []models.OrderStateAt{
+		{OrderNumber: "ORD_1", At: at, Status: "cooking", ChangedAt: at.Add(-time.Minute), ChangedBy: "chef"},
+		{OrderNumber: "ORD_2", At: at, Status: "received", ChangedAt: at.Add(-2 * time.Minute), ChangedBy: "order-service"},
+	}}
+	s := NewTrackingService(repo, nil, nil, clock.NewManual(at.Add(time.Hour)))
+
+	state, err := s.GetOrderStatusAt(context.Background(), "ORD_1", at)
+	if err != nil {
+		t.Fatalf("GetOrderStatusAt: %v", err)
+	}
+	if state != repo.states[0] {
+		t.Errorf("state = %+v, want %+v", state, repo.states[0])
+	}
+	// the requested moment is used as is, not replaced by the clock
+	if !repo.gotAt.Equal(at) {
+		t.Errorf("repository asked for %v, want %v", repo.gotAt, at)
+	}
+
+	if _, err := s.GetOrderStatusAt(context.Background(), "ORD_3", at); err == nil {
+		t.Error("order without a status at that moment should fail")
+	}
+
+	states, err := s.GetAllOrderStatusesAt(context.Background(), at)
+	if err != nil {
+		t.Fatalf("GetAllOrderStatusesAt: %v", err)
+	}
+	if !slices.Equal(states, repo.states) || !repo.gotAt.Equal(at) {
+		t.Errorf("states = %+v at %v, want %+v at %v", states, repo.gotAt, repo.states, at)
+	}
+}
+
+func TestGetOrderStatusesValidation(t *testing.T) {
+	tooMany := make([]string, MaxBatchStatus+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("ORD_%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		numbers []string
+	}{
+		{"empty", nil},
+		{"over the cap", tooMany},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackingService(batchOrderRepo{}, &countingWorkerRepo{}, &countingMetricsRepo{calls: make(map[string]int)}, clock.NewManual(time.Now()))
+			_, err := s.GetOrderStatuses(context.Background(), tt.numbers)
+			if err == nil || !strings.Contains(err.Error(), "validation failed") {
+				t.Errorf("err = %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+func TestGetOrderStatusesKeepsRequestOrder(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	// the repository returns what it found in its own order
+	orders := []models.OrderStatusResponse{
+		{OrderNumber: "ORD_3", OrderType: "takeout", CurrentStatus: "ready"},
+		{OrderNumber: "ORD_1", OrderType: "takeout", CurrentStatus: "completed"},
+	}
+	s := NewTrackingService(batchOrderRepo{orders: orders}, &countingWorkerRepo{}, &countingMetricsRepo{calls: make(map[string]int)}, clock.NewManual(now))
+
+	numbers := make([]string, MaxBatchStatus)
+	for i := range numbers {
+		numbers[i] = fmt.Sprintf("ORD_%d", i+1)
+	}
+	results, err := s.GetOrderStatuses(context.Background(), numbers)
+	if err != nil {
+		t.Fatalf("GetOrderStatuses: %v", err)
+	}
+	if len(results) != len(numbers) {
+		t.Fatalf("got %d results, want %d", len(results), len(numbers))
+	}
+	for i, result := range results {
+		wantFound := result.OrderNumber == "ORD_1" || result.OrderNumber == "ORD_3"
+		if result.OrderNumber != numbers[i] {
+			t.Errorf("result %d is %s, want %s", i, result.OrderNumber, numbers[i])
+		}
+		if result.Found != wantFound || (result.Order != nil) != wantFound {
+			t.Errorf("%s: found %v order %v, want found %v", result.OrderNumber, result.Found, result.Order, wantFound)
+		}
+		if result.Order != nil && result.Order.OrderNumber != result.OrderNumber {
+			t.Errorf("%s carries order %s", result.OrderNumber, result.Order.OrderNumber)
+		}
+	}
+}
+
+func TestIsWorkerOnline(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	seen := func(ago time.Duration) time.Time { return now.Add(-ago) }
+
+	tests := []struct {
+		name          string
+		worker        models.WorkerStatus
+		wantThreshold time.Duration
+		want          bool
+	}{
+		{"fresh heartbeat", models.WorkerStatus{HeartbeatIntervalSeconds: 10, LastSeen: seen(time.Second)}, 30 * time.Second, true},
+		{"at the threshold", models.WorkerStatus{HeartbeatIntervalSeconds: 10, LastSeen: seen(30 * time.Second)}, 30 * time.Second, true},
+		{"past the threshold", models.WorkerStatus{HeartbeatIntervalSeconds: 10, LastSeen: seen(31 * time.Second)}, 30 * time.Second, false},
+		{"default interval", models.WorkerStatus{LastSeen: seen(80 * time.Second)}, 90 * time.Second, true},
+		{"default interval expired", models.WorkerStatus{LastSeen: seen(91 * time.Second)}, 90 * time.Second, false},
+		{"clean shutdown", models.WorkerStatus{StoredStatus: "offline", HeartbeatIntervalSeconds: 10, LastSeen: now}, 30 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackingService(nil, nil, nil, clock.NewManual(now))
+			if got := livenessThreshold(tt.worker); got != tt.wantThreshold {
+				t.Errorf("livenessThreshold = %v, want %v", got, tt.wantThreshold)
+			}
+			if got := s.isWorkerOnline(tt.worker); got != tt.want {
+				t.Errorf("isWorkerOnline = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// detailWorkerRepo serves one worker and records what GetWorkerDetail asked for
+type detailWorkerRepo struct {
+	ports.WorkerRepository
+	worker models.WorkerStatus
+	orders map[string][]models.WorkerOrder
+	totals []models.DailyTotal
+
+	gotStatuses [][]string
+	gotLimits   []int
+	gotFrom     time.Time
+}
+
+func (r *detailWorkerRepo) GetWorkerStatus(ctx context.Context, workerName string) (models.WorkerStatus, error) {
+	if workerName != r.worker.WorkerName {
+		return models.WorkerStatus{}, fmt.Errorf("worker %s not found", workerName)
+	}
+	return r.worker, nil
+}
+
+func (r *detailWorkerRepo) GetWorkerOrders(ctx context.Context, workerName string, statuses []string, limit int) ([]models.WorkerOrder, error) {
+	r.gotStatuses = append(r.gotStatuses, statuses)
+	r.gotLimits = append(r.gotLimits, limit)
+	return r.orders[strings.Join(statuses, ",")], nil
+}
+
+func (r *detailWorkerRepo) GetWorkerDailyTotals(ctx context.Context, workerName string, from time.Time) ([]models.DailyTotal, error) {
+	r.gotFrom = from
+	return r.totals, nil
+}
+
+func TestGetWorkerDetail(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := &detailWorkerRepo{
+		worker: models.WorkerStatus{WorkerName: "chef", StoredStatus: "online", HeartbeatIntervalSeconds: 10, LastSeen: now.Add(-40 * time.Second)},
+		orders: map[string][]models.WorkerOrder{
+			"cooking":         {{OrderNumber: "ORD_3", Status: "cooking"}},
+			"ready,completed": {{OrderNumber: "ORD_2", Status: "ready"}, {OrderNumber: "ORD_1", Status: "completed"}},
+		},
+		totals: []models.DailyTotal{{Date: "2026-10-19", OrdersCooked: 3}},
+	}
+	s := NewTrackingService(nil, repo, nil, clock.NewManual(now))
+
+	detail, err := s.GetWorkerDetail(context.Background(), "chef")
+	if err != nil {
+		t.Fatalf("GetWorkerDetail: %v", err)
+	}
+
+	// a missed heartbeat window makes a worker offline even though it never wrote "offline"
+	if detail.Status != "offline" || detail.StoredStatus != "online" {
+		t.Errorf("status %q stored %q, want offline online", detail.Status, detail.StoredStatus)
+	}
+	if detail.LivenessThresholdSeconds != 30 {
+		t.Errorf("LivenessThresholdSeconds = %v, want 30", detail.LivenessThresholdSeconds)
+	}
+	if len(repo.gotStatuses) != 2 || !slices.Equal(repo.gotStatuses[0], []string{"cooking"}) ||
+		!slices.Equal(repo.gotStatuses[1], []string{"ready", "completed"}) {
+		t.Errorf("order statuses asked for = %v", repo.gotStatuses)
+	}
+	if !slices.Equal(repo.gotLimits, []int{workerRecentOrders, workerRecentOrders}) {
+		t.Errorf("order limits = %v, want %d each", repo.gotLimits, workerRecentOrders)
+	}
+	if len(detail.CurrentOrders) != 1 || detail.CurrentOrders[0].OrderNumber != "ORD_3" {
+		t.Errorf("CurrentOrders = %+v", detail.CurrentOrders)
+	}
+	if len(detail.RecentOrders) != 2 || detail.RecentOrders[0].OrderNumber != "ORD_2" {
+		t.Errorf("RecentOrders = %+v", detail.RecentOrders)
+	}
+	if want := now.AddDate(0, 0, -workerDailyTotalsDays); !repo.gotFrom.Equal(want) {
+		t.Errorf("daily totals from %v, want %v", repo.gotFrom, want)
+	}
+	if !slices.Equal(detail.DailyTotals, repo.totals) {
+		t.Errorf("DailyTotals = %+v, want %+v", detail.DailyTotals, repo.totals)
+	}
+
+	if _, err := s.GetWorkerDetail(context.Background(), "nobody"); err == nil {
+		t.Error("unknown worker should fail")
+	}
+}
@@ -0,0 +1,114 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the time source services use instead of calling the time package directly
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	// Scale is how many simulated seconds pass per real second
+	Scale() float64
+}
+
+// New returns the real clock for scale 1 and a scaled clock otherwise.
+// A scale of 60 makes one simulated minute pass in one real second.
+func New(scale float64) Clock {
+	if scale <= 0 || scale == 1 {
+		return Real{}
+	}
+	return Scaled{factor: scale}
+}
+
+// Real is backed by the time package
+type Real struct{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) Scale() float64                         { return 1 }
+
+// Scaled speeds up waits by factor. Now stays on the wall clock so timestamps
+// written by services running with different scales remain comparable; only
+// durations (After, Since) are in simulated time.
+type Scaled struct {
+	factor float64
+}
+
+func (c Scaled) Now() time.Time { return time.Now() }
+
+func (c Scaled) Since(t time.Time) time.Duration {
+	return time.Duration(float64(time.Since(t)) * c.factor)
+}
+
+func (c Scaled) After(d time.Duration) <-chan time.Time {
+	return time.After(time.Duration(float64(d) / c.factor))
+}
+
+func (c Scaled) Scale() float64 { return c.factor }
+
+// Manual only moves when Advance is called, for deterministic runs
+type Manual struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func NewManual(start time.Time) *Manual {
+	return &Manual{now: start}
+}
+
+func (c *Manual) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Manual) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *Manual) Scale() float64 { return 1 }
+
+func (c *Manual) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires every After whose deadline has passed
+func (c *Manual) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	sort.Slice(c.waiters, func(i, j int) bool {
+		return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+	})
+
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
@@ -0,0 +1,83 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		scale float64
+		want  float64
+	}{
+		{scale: 0, want: 1},
+		{scale: -5, want: 1},
+		{scale: 1, want: 1},
+		{scale: 60, want: 60},
+	}
+	for _, tt := range tests {
+		if got := New(tt.scale).Scale(); got != tt.want {
+			t.Errorf("New(%v).Scale() = %v, want %v", tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestScaledAfterIsShortened(t *testing.T) {
+	c := New(1000)
+	start := time.Now()
+	<-c.After(time.Second)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("After(1s) at scale 1000 took %v", elapsed)
+	}
+}
+
+func TestManualAdvanceFiresDueWaiters(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	c := NewManual(start)
+
+	short := c.After(5 * time.Second)
+	long := c.After(time.Minute)
+
+	c.Advance(4 * time.Second)
+	select {
+	case <-short:
+		t.Fatal("5s waiter fired after 4s")
+	default:
+	}
+
+	c.Advance(time.Second)
+	select {
+	case fired := <-short:
+		if want := start.Add(5 * time.Second); !fired.Equal(want) {
+			t.Errorf("5s waiter fired at %v, want %v", fired, want)
+		}
+	default:
+		t.Fatal("5s waiter did not fire after 5s")
+	}
+
+	select {
+	case <-long:
+		t.Fatal("1m waiter fired after 5s")
+	default:
+	}
+
+	c.Advance(time.Minute)
+	select {
+	case <-long:
+	default:
+		t.Fatal("1m waiter did not fire")
+	}
+
+	if got := c.Since(start); got != 65*time.Second {
+		t.Errorf("Since(start) = %v, want 65s", got)
+	}
+}
+
+func TestManualAfterNonPositiveFiresImmediately(t *testing.T) {
+	c := NewManual(time.Unix(0, 0))
+	select {
+	case <-c.After(0):
+	default:
+		t.Fatal("After(0) did not fire immediately")
+	}
+}
@@ -0,0 +1,221 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// The testdata fixtures are the wire format of every event version. A v0 fixture
+// is the message as published before the envelope existed; consumers must still
+// read it. v1 fixtures must also be what the current types marshal to.
+
+func at(clock string) time.Time {
+	t, err := time.Parse(time.RFC3339, "2026-10-19T"+clock+"Z")
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestEventFixtures(t *testing.T) {
+	orderEnvelope := Envelope{
+		EventID:       "9f2c0a41d8e34b0f8a1c2d3e4f506172",
+		EventType:     TypeOrderCreated,
+		Version:       OrderCreatedVersion,
+		OccurredAt:    at("12:34:56"),
+		CorrelationID: "9f2c0a41d8e34b0f8a1c2d3e4f506172",
+	}
+	orderPayload := OrderCreated{
+		OrderNumber:     "ORD_20261019_001",
+		CustomerName:    "Aigerim",
+		OrderType:       "delivery",
+		DeliveryAddress: ptr("Abay 10"),
+		Items:           []OrderItem{{Name: "Plov", Quantity: 2, Price: 3500}},
+		TotalAmount:     7000,
+		Priority:        5,
+	}
+	orderV1 := orderPayload
+	orderV1.Envelope = orderEnvelope
+
+	tests := []struct {
+		file    string
+		current bool
+		decode  func([]byte) (any, error)
+		want    any
+	}{
+		{
+			file:   "order_created.v0.json",
+			decode: decodeAs[OrderCreated],
+			want:   orderPayload,
+		},
+		{
+			file:    "order_created.v1.json",
+			current: true,
+			decode:  decodeAs[OrderCreated],
+			want:    orderV1,
+		},
+		{
+			file:   "order_status_changed.v0.json",
+			decode: decodeAs[OrderStatusChanged],
+			want: OrderStatusChanged{
+				OrderNumber: "ORD_20261019_001",
+				OldStatus:   "received",
+				NewStatus:   "cooking",
+				ChangedBy:   "chef_anna",
+				Timestamp:   at("12:35:00"),
+				// older kitchen builds sent a zero time when there was no estimate
+				EstimatedCompletion: ptr(time.Time{}),
+			},
+		},
+		{
+			file:    "order_status_changed.v1.json",
+			current: true,
+			decode:  decodeAs[OrderStatusChanged],
+			want: OrderStatusChanged{
+				Envelope: Envelope{
+					EventID:       "4b1e7c2a90d14f3e8b5a6c7d8e9f0a1b",
+					EventType:     TypeOrderStatusChanged,
+					Version:       OrderStatusChangedVersion,
+					OccurredAt:    at("12:35:00"),
+					CorrelationID: "9f2c0a41d8e34b0f8a1c2d3e4f506172",
+				},
+				OrderNumber:         "ORD_20261019_001",
+				OrderType:           "delivery",
+				OldStatus:           "received",
+				NewStatus:           "cooking",
+				ChangedBy:           "chef_anna",
+				Timestamp:           at("12:35:00"),
+				EstimatedCompletion: ptr(at("12:35:12")),
+			},
+		},
+		{
+			file:    "low_stock.v1.json",
+			current: true,
+			decode:  decodeAs[LowStock],
+			want: LowStock{
+				Envelope: Envelope{
+					EventID:    "c0ffee00c0ffee00c0ffee00c0ffee00",
+					EventType:  TypeLowStock,
+					Version:    LowStockVersion,
+					OccurredAt: at("12:35:01"),
+				},
+				Ingredient:        "rice",
+				Unit:              "kg",
+				StockQuantity:     1.5,
+				LowStockThreshold: 2,
+				OrderNumber:       "ORD_20261019_001",
+				Timestamp:         at("12:35:01"),
+			},
+		},
+		{
+			file:    "worker_command.v1.json",
+			current: true,
+			decode:  decodeAs[WorkerCommand],
+			want: WorkerCommand{
+				Envelope: Envelope{
+					EventID:    "0d15ea5e0d15ea5e0d15ea5e0d15ea5e",
+					EventType:  TypeWorkerCommand,
+					Version:    WorkerCommandVersion,
+					OccurredAt: at("13:00:00"),
+				},
+				Command:    "set_order_types",
+				OrderTypes: []string{"dine_in", "takeout"},
+				IssuedAt:   at("13:00:00"),
+			},
+		},
+		{
+			file:    "worker_command_ack.v1.json",
+			current: true,
+			decode:  decodeAs[WorkerCommandAck],
+			want: WorkerCommandAck{
+				Envelope: Envelope{
+					EventID:    "a11ce5a11ce5a11ce5a11ce5a11ce5a1",
+					EventType:  TypeWorkerCommandAck,
+					Version:    WorkerCommandAckVersion,
+					OccurredAt: at("13:00:01"),
+				},
+				WorkerName: "chef_anna",
+				Command:    "set_order_types",
+				Accepted:   true,
+				State:      "running",
+				OrderTypes: []string{"dine_in", "takeout"},
+				InFlight:   1,
+				Buffered:   2,
+				Timestamp:  at("13:00:01"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			fixture, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := tt.decode(fixture)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decoded\n%+v\nwant\n%+v", got, tt.want)
+			}
+
+			if !tt.current {
+				return
+			}
+			encoded, err := json.Marshal(tt.want)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			if !sameJSON(t, encoded, fixture) {
+				t.Errorf("encoded\n%s\nno longer matches %s", encoded, tt.file)
+			}
+		})
+	}
+}
+
+func TestPeekFixtures(t *testing.T) {
+	for file, wantType := range map[string]string{
+		"order_status_changed.v0.json": "",
+		"order_status_changed.v1.json": TypeOrderStatusChanged,
+		"low_stock.v1.json":            TypeLowStock,
+	} {
+		fixture, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		envelope, err := Peek(fixture)
+		if err != nil {
+			t.Fatalf("%s: Peek: %v", file, err)
+		}
+		if envelope.EventType != wantType {
+			t.Errorf("%s: event type %q, want %q", file, envelope.EventType, wantType)
+		}
+	}
+}
+
+func decodeAs[T any](data []byte) (any, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// sameJSON compares documents regardless of key order and whitespace
+func sameJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
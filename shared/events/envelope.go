@@ -0,0 +1,84 @@
+package events
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event types carried in Envelope.EventType
+const (
+	TypeOrderCreated       = "order.created"
+	TypeOrderStatusChanged = "order.status_changed"
+	TypeLowStock           = "inventory.low_stock"
+	TypeWorkerCommand      = "worker.command"
+	TypeWorkerCommandAck   = "worker.command_ack"
+)
+
+// Current schema version of each event type. Bump a version only for changes
+// old consumers cannot read; adding optional fields keeps the version.
+const (
+	OrderCreatedVersion       = 1
+	OrderStatusChangedVersion = 1
+	LowStockVersion           = 1
+	WorkerCommandVersion      = 1
+	WorkerCommandAckVersion   = 1
+)
+
+// Envelope is embedded in every message, so its fields sit at the top level of
+// the JSON next to the payload. Messages published before the envelope existed
+// decode with empty envelope fields.
+type Envelope struct {
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// CorrelationID ties together every event caused by one order or request
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// NewEnvelope fills in a fresh event id
+func NewEnvelope(eventType string, version int, correlationID string, occurredAt time.Time) (Envelope, error) {
+	eventID, err := NewEventID()
+	if err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{
+		EventID:       eventID,
+		EventType:     eventType,
+		Version:       version,
+		OccurredAt:    occurredAt,
+		CorrelationID: correlationID,
+	}, nil
+}
+
+// NewEventID returns a random 128-bit id in hex
+func NewEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate event id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// TransitionEventID derives a status change's event id from the change itself.
+// statusLogID is the order_status_log row written for the change, so republishing
+// that row repeats the id and consumers drop it as a duplicate. A kitchen retry of
+// the same transition (a redelivered order cooked again) writes a new row and gets
+// a new id; consumers have to drop it by the order's status sequence instead.
+func TransitionEventID(orderNumber, newStatus string, statusLogID int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d", orderNumber, newStatus, statusLogID)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Peek decodes only the envelope, to pick the message type before decoding the rest
+func Peek(body []byte) (Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Envelope{}, fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+	return envelope, nil
+}
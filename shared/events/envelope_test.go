@@ -0,0 +1,24 @@
+package events
+
+import "testing"
+
+func TestTransitionEventID(t *testing.T) {
+	id := TransitionEventID("ORD_20261019_001", "cooking", 42)
+	if len(id) != 32 {
+		t.Errorf("id %q is not 128 bits of hex", id)
+	}
+	if again := TransitionEventID("ORD_20261019_001", "cooking", 42); again != id {
+		t.Errorf("republished transition got %q, want %q", again, id)
+	}
+
+	others := []string{
+		TransitionEventID("ORD_20261019_002", "cooking", 42),
+		TransitionEventID("ORD_20261019_001", "ready", 42),
+		TransitionEventID("ORD_20261019_001", "cooking", 43),
+	}
+	for _, other := range others {
+		if other == id {
+			t.Errorf("a different transition shares id %q", id)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package events
+
+import "time"
+
+// LowStock is broadcast on notifications_fanout when an ingredient crosses its threshold
+type LowStock struct {
+	Envelope
+	Ingredient        string    `json:"ingredient"`
+	Unit              string    `json:"unit"`
+	StockQuantity     float64   `json:"stock_quantity"`
+	LowStockThreshold float64   `json:"low_stock_threshold"`
+	OrderNumber       string    `json:"order_number"`
+	Timestamp         time.Time `json:"timestamp"`
+}
@@ -1,9 +0,0 @@
-package events
-
-const (
-	ExchangeOrders        = "orders_topic"
-	RoutingKeyOrderCreate = "order.created"
-	RoutingKeyOrderStatus = "order.status"
-	QueueKitchen          = "orders"
-	ExchangeStatusUpdates = "status_updates"
-)
@@ -0,0 +1,38 @@
+package events
+
+import "time"
+
+// OrderCreated goes from order-service to the kitchen on orders_topic
+// with routing key kitchen.<order_type>.<priority>.
+// The payload keys are PascalCase: they predate the shared contract and stay
+// as they are so orders already queued keep decoding.
+type OrderCreated struct {
+	Envelope
+	OrderNumber     string      `json:"OrderNumber"`
+	CustomerName    string      `json:"CustomerName"`
+	OrderType       string      `json:"OrderType"`
+	TableNumber     *int        `json:"TableNumber"`
+	DeliveryAddress *string     `json:"DeliveryAddress"`
+	Items           []OrderItem `json:"Items"`
+	TotalAmount     float64     `json:"TotalAmount"`
+	Priority        int         `json:"Priority"`
+}
+
+type OrderItem struct {
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+// OrderStatusChanged is broadcast on notifications_fanout for every order status transition
+type OrderStatusChanged struct {
+	Envelope
+	OrderNumber string    `json:"order_number"`
+	OrderType   string    `json:"order_type,omitempty"`
+	OldStatus   string    `json:"old_status"`
+	NewStatus   string    `json:"new_status"`
+	ChangedBy   string    `json:"changed_by"`
+	Timestamp   time.Time `json:"timestamp"`
+	// EstimatedCompletion is set when the publisher knows when the order will be ready
+	EstimatedCompletion *time.Time `json:"estimated_completion,omitempty"`
+}
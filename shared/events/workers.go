@@ -0,0 +1,26 @@
+package events
+
+import "time"
+
+// WorkerCommand is sent on orders_topic with routing key control.<worker>;
+// the AMQP correlation id and reply-to route the WorkerCommandAck back
+type WorkerCommand struct {
+	Envelope
+	Command    string    `json:"command"`
+	OrderTypes []string  `json:"order_types,omitempty"`
+	IssuedAt   time.Time `json:"issued_at"`
+}
+
+// WorkerCommandAck is the worker's answer to a WorkerCommand
+type WorkerCommandAck struct {
+	Envelope
+	WorkerName string    `json:"worker_name"`
+	Command    string    `json:"command"`
+	Accepted   bool      `json:"accepted"`
+	State      string    `json:"state"`
+	OrderTypes []string  `json:"order_types"`
+	InFlight   int       `json:"in_flight"`
+	Buffered   int       `json:"buffered"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
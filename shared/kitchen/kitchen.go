@@ -0,0 +1,78 @@
+// Package kitchen holds the kitchen's timing rules shared by the services:
+// the kitchen cooks by them, tracking estimates and audits against them.
+package kitchen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CookTimes is the simulated cook time per order type
+var CookTimes = map[string]time.Duration{
+	"dine_in":  8 * time.Second,
+	"takeout":  10 * time.Second,
+	"delivery": 12 * time.Second,
+}
+
+// DefaultCookTime is used for order types missing from CookTimes
+const DefaultCookTime = 10 * time.Second
+
+// WaitTier is how long an order of at least MinPriority may wait for the stove
+type WaitTier struct {
+	MinPriority int
+	MaxWait     time.Duration
+}
+
+// WaitTiers is ordered from the highest priority down; the last tier catches the rest
+var WaitTiers = []WaitTier{
+	{MinPriority: 10, MaxWait: 1 * time.Minute},
+	{MinPriority: 5, MaxWait: 3 * time.Minute},
+	{MinPriority: 0, MaxWait: 5 * time.Minute},
+}
+
+// CookingTime is how long the kitchen cooks an order of this type
+func CookingTime(orderType string) time.Duration {
+	if d, ok := CookTimes[orderType]; ok {
+		return d
+	}
+	return DefaultCookTime
+}
+
+// MaxWait is how long an order may wait for the stove at this priority
+func MaxWait(priority int) time.Duration {
+	for _, tier := range WaitTiers {
+		if priority >= tier.MinPriority {
+			return tier.MaxWait
+		}
+	}
+	return WaitTiers[len(WaitTiers)-1].MaxWait
+}
+
+// DeadlineSQL is MaxWait + CookingTime after receivedAt as a PostgreSQL expression,
+// built from the same tables so queries cannot drift from the kitchen. Both are
+// simulated time, so they are divided by the timeScale expression.
+func DeadlineSQL(receivedAt, orderType, priority, timeScale string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s + (CASE", receivedAt)
+	for _, tier := range WaitTiers {
+		fmt.Fprintf(&b, " WHEN coalesce(%s, 1) >= %d THEN %s", priority, tier.MinPriority, sqlInterval(tier.MaxWait))
+	}
+	fmt.Fprintf(&b, " ELSE %s END + CASE %s", sqlInterval(WaitTiers[len(WaitTiers)-1].MaxWait), orderType)
+
+	types := make([]string, 0, len(CookTimes))
+	for t := range CookTimes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %s", t, sqlInterval(CookTimes[t]))
+	}
+	fmt.Fprintf(&b, " ELSE %s END) / %s", sqlInterval(DefaultCookTime), timeScale)
+	return b.String()
+}
+
+func sqlInterval(d time.Duration) string {
+	return fmt.Sprintf("interval '%g seconds'", d.Seconds())
+}
@@ -0,0 +1,49 @@
+package kitchen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaxWait(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     time.Duration
+	}{
+		{15, time.Minute},
+		{10, time.Minute},
+		{7, 3 * time.Minute},
+		{5, 3 * time.Minute},
+		{1, 5 * time.Minute},
+		{-1, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := MaxWait(tt.priority); got != tt.want {
+			t.Errorf("MaxWait(%d) = %v, want %v", tt.priority, got, tt.want)
+		}
+	}
+}
+
+func TestCookingTime(t *testing.T) {
+	if got := CookingTime("delivery"); got != 12*time.Second {
+		t.Errorf("CookingTime(delivery) = %v", got)
+	}
+	if got := CookingTime("catering"); got != DefaultCookTime {
+		t.Errorf("CookingTime(catering) = %v, want the default", got)
+	}
+}
+
+func TestDeadlineSQL(t *testing.T) {
+	want := "o.created_at + (CASE" +
+		" WHEN coalesce(o.priority, 1) >= 10 THEN interval '60 seconds'" +
+		" WHEN coalesce(o.priority, 1) >= 5 THEN interval '180 seconds'" +
+		" WHEN coalesce(o.priority, 1) >= 0 THEN interval '300 seconds'" +
+		" ELSE interval '300 seconds' END + CASE o.type" +
+		" WHEN 'delivery' THEN interval '12 seconds'" +
+		" WHEN 'dine_in' THEN interval '8 seconds'" +
+		" WHEN 'takeout' THEN interval '10 seconds'" +
+		" ELSE interval '10 seconds' END) / $4::float8"
+	if got := DeadlineSQL("o.created_at", "o.type", "o.priority", "$4::float8"); got != want {
+		t.Errorf("DeadlineSQL =\n%s\nwant\n%s", got, want)
+	}
+}